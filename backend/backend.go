@@ -0,0 +1,96 @@
+// Package backend define the interface between a finished type
+// conversion and the code generators that are writing output files.
+package backend
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// Backend is producing output files from a finished conversion
+type Backend interface {
+	// Name is used to select the backend on command line
+	Name() string
+
+	// Description is a single line help text
+	Description() string
+
+	// WriteSource is creating output files
+	WriteSource(conv *types.Convert) ([]*Source, error)
+}
+
+// Source is a single output file
+type Source struct {
+	// Package is the package (folder) the file belongs to
+	Package string
+
+	// Name is the filename without any folder
+	Name string
+
+	// Content is the file content
+	Content []byte
+}
+
+var registry = make(map[string]Backend)
+
+// Register is adding a backend that can be selected by name.
+// Registering the same name twice will panic.
+func Register(b Backend) {
+	name := b.Name()
+	if _, found := registry[name]; found {
+		panic(fmt.Sprintf("backend '%s' is already registered", name))
+	}
+	registry[name] = b
+}
+
+// Get is returning the backend with given name
+func Get(name string) (Backend, bool) {
+	b, found := registry[name]
+	return b, found
+}
+
+// List is returning all registered backends, sorted by name
+func List() []Backend {
+	ret := make([]Backend, 0, len(registry))
+	for _, b := range registry {
+		ret = append(ret, b)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name() < ret[j].Name() })
+	return ret
+}
+
+// Names is returning all registered backend names, sorted
+func Names() []string {
+	ret := []string{}
+	for _, b := range List() {
+		ret = append(ret, b.Name())
+	}
+	return ret
+}
+
+// Filename is returning the output filename for the source. If
+// insidePkg is set, the filename is relative to that package and
+// files outside of it are not included.
+func (src *Source) Filename(insidePkg string) (string, bool) {
+	full := filepath.Join(src.Package, src.Name)
+	if insidePkg == "" {
+		return full, true
+	}
+	limit := insidePkg
+	if !strings.HasSuffix(limit, "/") {
+		limit = limit + "/"
+	} else {
+		insidePkg = insidePkg[0 : len(insidePkg)-1]
+	}
+	if src.Package == insidePkg {
+		return src.Name, true
+	}
+	if strings.HasPrefix(src.Package, limit) {
+		return full[len(limit):], true
+	}
+	return full, false
+}
@@ -0,0 +1,36 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceFilename(t *testing.T) {
+	src := &Source{
+		Name:    "hello.go",
+		Package: "github.com/gowebapi/webapi",
+	}
+	filename, inc := src.Filename("")
+	assert.True(t, inc)
+	assert.Equal(t, "github.com/gowebapi/webapi/hello.go", filename)
+
+	filename, inc = src.Filename("foo")
+	assert.False(t, inc)
+
+	filename, inc = src.Filename("github.com/gowebapi")
+	assert.True(t, inc)
+	assert.Equal(t, "webapi/hello.go", filename)
+
+	filename, inc = src.Filename("github.com/gowebapi/")
+	assert.True(t, inc)
+	assert.Equal(t, "webapi/hello.go", filename)
+
+	filename, inc = src.Filename("github.com/gowebapi/webapi")
+	assert.True(t, inc)
+	assert.Equal(t, "hello.go", filename)
+
+	filename, inc = src.Filename("github.com/gowebapi/webapi/")
+	assert.True(t, inc)
+	assert.Equal(t, "hello.go", filename)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/backend"
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/transform"
+)
+
+// command is a subcommand on command line
+type command struct {
+	name  string
+	usage string
+	run   func() error
+
+	// needOutput is true if -output is required
+	needOutput bool
+
+	// argument is the name of a required argument before input
+	// files, if any
+	argument string
+}
+
+// commands in usage order, first is default
+var commands = []*command{
+	{"generate", "generate source code for selected target (default)", runGenerate, true, ""},
+	{"check", "validate WebIDL and transform files without writing anything", runCheck, false, ""},
+	{"status", "write the spec status markdown file given with -spec-status", runStatus, false, ""},
+	{"crossref", "write the javascript cross reference file given with -cross-ref", runCrossRef, false, ""},
+	{"dump", "print the type model as JSON, one document per package", runDump, false, ""},
+	{"explain", "print how Type.member got its Go name, package and signature", runExplain, false, "Type.member"},
+	{"lint", "report unused and conflicting transform rules and Go name clashes", runLint, false, ""},
+	{"fmt", "rewrite transform files in canonical format, with -l only list them", runFmt, false, ""},
+	{"tojson", "convert .md transform files into .transform.json files", runToJSON, false, ""},
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// gowasmBackend is the registered gowasm backend, used by commands
+// that is inspecting generated Go source code
+func gowasmBackend() (*gowasm.Backend, error) {
+	if b, found := backend.Get("gowasm"); found {
+		if gen, ok := b.(*gowasm.Backend); ok {
+			return gen, nil
+		}
+	}
+	return nil, errors.New("gowasm backend is not registered")
+}
+
+func runCheck() error {
+	p, err := loadAndTransform(os.Stdout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("no errors found in %d types\n", len(p.conv.All))
+	return nil
+}
+
+func runStatus() error {
+	if args.statusFile == "" {
+		return errors.New("missing -spec-status output file")
+	}
+	p, err := loadAndTransform(os.Stdout)
+	if err != nil {
+		return err
+	}
+	return p.trans.WriteMarkdownStatus(args.statusFile)
+}
+
+func runCrossRef() error {
+	if args.crossRef == "" {
+		return errors.New("missing -cross-ref output file")
+	}
+	p, err := loadAndTransform(os.Stdout)
+	if err != nil {
+		return err
+	}
+	return p.trans.WriteCrossReference(args.crossRef)
+}
+
+func runDump() error {
+	// keep stdout clean for the json output
+	p, err := loadAndTransform(os.Stderr)
+	if err != nil {
+		return err
+	}
+	target, found := backend.Get("json")
+	if !found {
+		return errors.New("json backend is not registered")
+	}
+	files, err := target.WriteSource(p.conv)
+	if err != nil {
+		return err
+	}
+	for _, src := range files {
+		if _, err := os.Stdout.Write(src.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runFmt() error {
+	for _, name := range args.inputs {
+		if filepath.Ext(name) != ".md" {
+			continue
+		}
+		content, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		out, err := transform.Format(name, content)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(content, out) {
+			continue
+		}
+		if args.fmtList {
+			fmt.Println(name)
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(name, out, info.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runToJSON() error {
+	for _, name := range args.inputs {
+		if filepath.Ext(name) != ".md" {
+			continue
+		}
+		content, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		out, err := transform.ConvertToJSON(name, content)
+		if err != nil {
+			return err
+		}
+		target := strings.TrimSuffix(strings.TrimSuffix(name, ".md"), ".go") + transform.JSONSuffix
+		if pathExist(target) && !args.force {
+			return fmt.Errorf("%s already exist, use -force to overwrite", target)
+		}
+		fmt.Println("writing", target)
+		if err := ioutil.WriteFile(target, out, 0664); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+)
+
+// defaultConfigFile is used if no input files are given on command line
+const defaultConfigFile = "webidl-bind.json"
+
+// config is a project configuration file. All paths are relative
+// to the folder of the configuration file. Command line flags
+// override values in the file.
+type config struct {
+	// Idl and Transform is a list of glob patterns. '**' is
+	// matching any number of folders.
+	Idl       []string `json:"idl"`
+	Transform []string `json:"transform"`
+
+	Output        string `json:"output"`
+	Module        string `json:"module"`
+	SinglePackage string `json:"single-package"`
+	Target        string `json:"target"`
+	GoBuild       string `json:"go-build"`
+	GoTest        string `json:"go-test"`
+	Check         *bool  `json:"check"`
+	SpecStatus    string `json:"spec-status"`
+	CrossRef      string `json:"cross-ref"`
+	LogWarning    *bool  `json:"log-warning"`
+	Templates     string `json:"templates"`
+
+	Gowasm   configGowasm            `json:"gowasm"`
+	Packages map[string]configGowasm `json:"packages"`
+
+	// folder of config file
+	dir string
+}
+
+// configGowasm is gowasm options, nil values are not changed
+type configGowasm struct {
+	SharedConvert  *bool  `json:"shared-convert"`
+	CallbackScope  *bool  `json:"callback-scope"`
+	FilePerType    *bool  `json:"file-per-type"`
+	LineDirectives *bool  `json:"line-directives"`
+	FakeJS         string `json:"fake-js"`
+}
+
+func loadConfig(filename string) (*config, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config{dir: filepath.Dir(filename)}
+	if err := json.Unmarshal(content, conf); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return conf, nil
+}
+
+// apply is setting all values in args that wasn't given on command line
+func (c *config) apply(set map[string]bool) error {
+	str := func(name string, dst *string, value string, path bool) {
+		if !set[name] && value != "" {
+			if path {
+				value = c.path(value)
+			}
+			*dst = value
+		}
+	}
+	boolean := func(name string, dst *bool, value *bool) {
+		if !set[name] && value != nil {
+			*dst = *value
+		}
+	}
+	str("output", &args.outputPath, c.Output, true)
+	str("inside-package", &args.insidePkg, c.Module, false)
+	str("single-package", &args.singlePkg, c.SinglePackage, false)
+	str("target", &args.target, c.Target, false)
+	str("go-build", &args.goBuild, c.GoBuild, false)
+	str("go-test", &args.goTest, c.GoTest, false)
+	boolean("check", &args.check, c.Check)
+	str("spec-status", &args.statusFile, c.SpecStatus, true)
+	str("cross-ref", &args.crossRef, c.CrossRef, true)
+	boolean("log-warning", &args.warnings, c.LogWarning)
+	str("templates", &args.templates, c.Templates, true)
+	c.Gowasm.apply(&args.gowasm, set)
+	if len(c.Packages) > 0 {
+		args.gowasm.Packages = make(map[string]gowasm.Options)
+		for pkg, override := range c.Packages {
+			opts := args.gowasm
+			opts.Packages = nil
+			override.apply(&opts, set)
+			args.gowasm.Packages[pkg] = opts
+		}
+	}
+
+	if len(args.inputs) > 0 {
+		// command line is replacing input files
+		return nil
+	}
+	var unmatched []string
+	for _, list := range [][]string{c.Idl, c.Transform} {
+		for _, pattern := range list {
+			files, err := expandGlob(c.path(pattern))
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				unmatched = append(unmatched, pattern)
+			}
+			args.inputs = append(args.inputs, files...)
+		}
+	}
+	for _, pattern := range unmatched {
+		fmt.Fprintf(os.Stderr, "warning: config entry '%s' doesn't match any file\n", pattern)
+	}
+	return nil
+}
+
+func (c *configGowasm) apply(opts *gowasm.Options, set map[string]bool) {
+	values := []struct {
+		name  string
+		dst   *bool
+		value *bool
+	}{
+		{"shared-convert", &opts.SharedConvert, c.SharedConvert},
+		{"callback-scope", &opts.CallbackScope, c.CallbackScope},
+		{"file-per-type", &opts.FilePerType, c.FilePerType},
+		{"line-directives", &opts.LineDirectives, c.LineDirectives},
+	}
+	for _, v := range values {
+		if !set[v.name] && v.value != nil {
+			*v.dst = *v.value
+		}
+	}
+	if !set["fake-js"] && c.FakeJS != "" {
+		opts.FakeJS = c.FakeJS
+	}
+}
+
+// path is making a path relative to the config file
+func (c *config) path(value string) string {
+	if filepath.IsAbs(value) {
+		return value
+	}
+	return filepath.Join(c.dir, value)
+}
+
+// flagsOnCommandLine is returning all flags that is given on
+// command line
+func flagsOnCommandLine() map[string]bool {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// expandGlob is returning all files matching pattern. Except for
+// normal filepath.Match syntax, '**' is matching any number of folders.
+func expandGlob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return onlyFiles(files), nil
+	}
+	pattern = filepath.ToSlash(pattern)
+	// walk from the folder before first wildcard
+	root := pattern[:strings.IndexAny(pattern, "*?[")]
+	if idx := strings.LastIndex(root, "/"); idx != -1 {
+		root = root[:idx+1]
+	} else {
+		root = ""
+	}
+	re, err := globRegexp(pattern[len(root):])
+	if err != nil {
+		return nil, err
+	}
+	var ret []string
+	start := root
+	if start == "" {
+		start = "."
+	}
+	err = filepath.Walk(filepath.FromSlash(start), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == filepath.FromSlash(start) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(start), path)
+		if err != nil {
+			return err
+		}
+		if re.MatchString(filepath.ToSlash(rel)) {
+			ret = append(ret, path)
+		}
+		return nil
+	})
+	sort.Strings(ret)
+	return ret, err
+}
+
+// globRegexp is converting a glob pattern into a regular expression
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var out strings.Builder
+	out.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			out.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			out.WriteString(".*")
+			i++
+		case c == '*':
+			out.WriteString("[^/]*")
+		case c == '?':
+			out.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid pattern '%s': missing ']'", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			out.WriteString("[" + class + "]")
+			i += end
+		default:
+			out.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	out.WriteString("$")
+	return regexp.Compile(out.String())
+}
+
+func onlyFiles(list []string) []string {
+	ret := []string{}
+	for _, name := range list {
+		if fi, err := os.Stat(name); err == nil && !fi.IsDir() {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
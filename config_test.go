@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.idl", "x/b.idl", "x/y/c.idl", "x/y/c.md", "z/d.idl"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0775))
+		assert.Nil(t, ioutil.WriteFile(path, nil, 0664))
+	}
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"*.idl", []string{"a.idl"}},
+		{"**/*.idl", []string{"a.idl", "x/b.idl", "x/y/c.idl", "z/d.idl"}},
+		{"x/**", []string{"x/b.idl", "x/y/c.idl", "x/y/c.md"}},
+		{"x/**/c.*", []string{"x/y/c.idl", "x/y/c.md"}},
+		{"[!x]*/**/*.idl", []string{"z/d.idl"}},
+		{"missing/**/*.idl", []string{}},
+	}
+	for _, test := range tests {
+		files, err := expandGlob(filepath.Join(dir, test.pattern))
+		assert.Nil(t, err, test.pattern)
+		rel := []string{}
+		for _, f := range files {
+			r, _ := filepath.Rel(dir, f)
+			rel = append(rel, filepath.ToSlash(r))
+		}
+		assert.Equal(t, test.expected, rel, test.pattern)
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "dom.idl"), nil, 0664))
+	filename := filepath.Join(dir, defaultConfigFile)
+	content := `{
+	"idl": ["*.idl", "missing/*.idl"],
+	"output": "out",
+	"module": "github.com/gowebapi",
+	"go-build": "wasm",
+	"gowasm": {"shared-convert": true, "callback-scope": true},
+	"packages": {"github.com/gowebapi/webapi/html": {"file-per-type": true}}
+}`
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(content), 0664))
+	conf, err := loadConfig(filename)
+	if !assert.Nil(t, err) {
+		return
+	}
+	saved := args
+	defer func() { args = saved }()
+	args.inputs = nil
+	args.goBuild = "host"
+	args.gowasm.CallbackScope = false
+	err = conf.apply(map[string]bool{"go-build": true, "callback-scope": true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "dom.idl")}, args.inputs)
+	assert.Equal(t, filepath.Join(dir, "out"), args.outputPath)
+	assert.Equal(t, "github.com/gowebapi", args.insidePkg)
+	assert.Equal(t, "host", args.goBuild)
+	assert.True(t, args.gowasm.SharedConvert)
+	assert.False(t, args.gowasm.CallbackScope)
+	html := args.gowasm.Packages["github.com/gowebapi/webapi/html"]
+	assert.True(t, html.FilePerType)
+	assert.True(t, html.SharedConvert)
+	assert.False(t, args.gowasm.FilePerType)
+}
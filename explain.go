@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// explained is a type or member that explain is printing
+type explained struct {
+	kind string
+	name *types.MethodName
+	ref  *types.Ref
+}
+
+func runExplain() error {
+	typeName, member := splitExplainArg(args.commandArg)
+	if typeName == "" {
+		return errors.New("expected argument in form Type or Type.member")
+	}
+	types.TraceChanges = true
+	defer func() { types.TraceChanges = false }()
+	// keep stdout clean for the explanation
+	p, err := loadAndTransform(os.Stderr)
+	if err != nil {
+		return err
+	}
+	gen, err := gowasmBackend()
+	if err != nil {
+		return err
+	}
+	gen.Log = os.Stderr
+	return explain(os.Stdout, p.conv, typeName, member, gen)
+}
+
+func splitExplainArg(value string) (string, string) {
+	if idx := strings.Index(value, "."); idx != -1 {
+		return value[:idx], value[idx+1:]
+	}
+	return value, ""
+}
+
+// explain is writing the history of a type or a member, from WebIDL
+// declaration to the generated Go declarations
+func explain(dst io.Writer, conv *types.Convert, typeName, member string, gen *gowasm.Backend) error {
+	value, found := conv.Types[typeName]
+	if !found {
+		return fmt.Errorf("unknown type '%s'", typeName)
+	}
+	basic := value.Basic()
+	fmt.Fprintf(dst, "%s %s\n", typeKind(value), basic.Idl)
+	fmt.Fprintf(dst, "  package: %s\n", basic.Package)
+	fmt.Fprintf(dst, "  go name: %s\n", basic.Def)
+	for _, ref := range value.AllSourceReferences() {
+		fmt.Fprintf(dst, "  defined at %s\n", ref)
+	}
+	printTrace(dst, value.SourceReference(), "  ")
+	targets := []*explained{}
+	if member == "" {
+		targets = append(targets, &explained{kind: "type", ref: value.SourceReference()})
+	} else {
+		targets = explainMembers(value, member)
+		if len(targets) == 0 {
+			return fmt.Errorf("type '%s' doesn't have any member '%s'", typeName, member)
+		}
+	}
+	decls, err := explainDecls(conv, basic.Package, gen)
+	if err != nil {
+		return err
+	}
+	for _, m := range targets {
+		if m.name != nil {
+			fmt.Fprintf(dst, "\n%s %s.%s\n", m.kind, basic.Idl, m.name.Idl)
+			fmt.Fprintf(dst, "  go name: %s\n", m.name.Def)
+			fmt.Fprintf(dst, "  defined at %s\n", m.ref)
+			printTrace(dst, m.ref, "  ")
+		}
+		fmt.Fprintln(dst, "  go declarations:")
+		list := decls[m.ref]
+		if len(list) == 0 {
+			fmt.Fprintln(dst, "    (none)")
+		}
+		for _, decl := range list {
+			fmt.Fprintf(dst, "    %s\n", decl)
+		}
+	}
+	return nil
+}
+
+func typeKind(value types.Type) string {
+	switch value.(type) {
+	case *types.Interface:
+		return "interface"
+	case *types.Dictionary:
+		return "dictionary"
+	case *types.Enum:
+		return "enum"
+	case *types.Callback:
+		return "callback"
+	}
+	return "type"
+}
+
+func printTrace(dst io.Writer, ref *types.Ref, indent string) {
+	for _, line := range ref.Trace {
+		fmt.Fprintf(dst, "%s%s\n", indent, line)
+	}
+}
+
+// explainMembers is returning all members with given WebIDL name
+func explainMembers(value types.Type, name string) []*explained {
+	return findMembers(value, func(n *types.MethodName) bool { return n.Idl == name })
+}
+
+// findMembers is returning all members where match is true
+func findMembers(value types.Type, match func(name *types.MethodName) bool) []*explained {
+	ret := []*explained{}
+	add := func(kind string, member interface {
+		types.GetRef
+		Name() *types.MethodName
+	}) {
+		if member != nil && match(member.Name()) {
+			ret = append(ret, &explained{kind: kind, name: member.Name(), ref: member.SourceReference()})
+		}
+	}
+	switch value := value.(type) {
+	case *types.Interface:
+		for _, m := range value.Consts {
+			add("const", m)
+		}
+		for _, m := range value.Vars {
+			add("attribute", m)
+		}
+		for _, m := range value.StaticVars {
+			add("static attribute", m)
+		}
+		for _, m := range value.Events {
+			add("event", m)
+		}
+		for _, m := range value.Method {
+			add("method", m)
+		}
+		for _, m := range value.StaticMethod {
+			add("static method", m)
+		}
+	case *types.Dictionary:
+		for _, m := range value.Members {
+			add("member", m)
+		}
+	}
+	return ret
+}
+
+// explainDecls is generating source code for a package and return
+// all declarations indexed by WebIDL source reference
+func explainDecls(conv *types.Convert, pkg string, gen *gowasm.Backend) (map[*types.Ref][]string, error) {
+	files, err := gen.WriteSource(conv)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[*types.Ref][]string)
+	for _, src := range files {
+		if src.Package != pkg || !strings.HasSuffix(src.Name, "_js.go") {
+			continue
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, src.Name, src.Content, 0)
+		if err != nil {
+			return nil, err
+		}
+		table := gen.LineTable(src)
+		for _, d := range file.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			entry := table.Lookup(fset.PositionFor(fn.Pos(), false).Line)
+			if entry == nil || entry.Ref == nil {
+				continue
+			}
+			fn.Body = nil
+			fn.Doc = nil
+			var out bytes.Buffer
+			if err := printer.Fprint(&out, fset, fn); err != nil {
+				return nil, err
+			}
+			ret[entry.Ref] = append(ret[entry.Ref], out.String())
+		}
+	}
+	for _, list := range ret {
+		sort.Strings(list)
+	}
+	return ret, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const explainIdl = `
+callback EventHandlerNonNull = any (Event event);
+typedef EventHandlerNonNull? EventHandler;
+interface Event { };
+interface MouseEvent : Event { };
+interface HTMLElement {
+  void click();
+  void click(long x);
+};
+partial interface HTMLElement {
+  attribute long extra;
+};
+interface mixin GlobalEventHandlers {
+  attribute EventHandler onclick;
+};
+HTMLElement includes GlobalEventHandlers;
+callback PromiseCallback = void(any value);
+interface Promise { };
+`
+
+const explainTransform = `# explain
+
+.title = internal
+
+## HTMLElement
+
+.name = Element
+extra = Additional
+@event Click MouseEvent
+`
+
+func TestExplain(t *testing.T) {
+	dir := t.TempDir()
+	idl := filepath.Join(dir, "explain.idl")
+	md := filepath.Join(dir, "explain.md")
+	assert.Nil(t, ioutil.WriteFile(idl, []byte(explainIdl), 0664))
+	assert.Nil(t, ioutil.WriteFile(md, []byte(explainTransform), 0664))
+	saved := args
+	defer func() { args = saved }()
+	args.inputs = []string{idl, md}
+	args.singlePkg = ""
+	types.TraceChanges = true
+	defer func() { types.TraceChanges = false }()
+	p, err := loadAndTransform(ioutil.Discard)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	tests := []struct {
+		member   string
+		expected []string
+	}{
+		{"onclick", []string{
+			"included from mixin GlobalEventHandlers by includes statement at " + idl + ":16",
+			"event click of type MouseEvent added by " + md + ":9",
+			"func (_this *Element) SetOnClick(listener func(event *MouseEvent, currentTarget *Element)) js.Func",
+		}},
+		{"click", []string{
+			"override renamed from Click to Click2",
+			"func (_this *Element) Click()",
+			"func (_this *Element) Click2(x int)",
+		}},
+		{"extra", []string{
+			"merged from partial interface HTMLElement at " + idl + ":10",
+			"renamed to Additional by " + md + ":8",
+			"func (_this *Element) SetAdditional(value int)",
+		}},
+	}
+	for _, test := range tests {
+		var out strings.Builder
+		err := explain(&out, p.conv, "HTMLElement", test.member, gowasm.NewBackend(&args.gowasm))
+		if !assert.Nil(t, err, test.member) {
+			continue
+		}
+		text := out.String()
+		assert.Contains(t, text, "property name = Element by "+md+":7", test.member)
+		for _, line := range test.expected {
+			assert.Contains(t, text, line, test.member)
+		}
+	}
+
+	var out strings.Builder
+	assert.NotNil(t, explain(&out, p.conv, "HTMLElement", "missing", gowasm.NewBackend(&args.gowasm)))
+	assert.NotNil(t, explain(&out, p.conv, "Missing", "", gowasm.NewBackend(&args.gowasm)))
+}
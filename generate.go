@@ -0,0 +1,295 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/backend"
+	"github.com/gowebapi/webidl-bind/gocheck"
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/output"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// runGenerate is writing output files for selected target
+func runGenerate() error {
+	if args.writeTmpl != "" {
+		fmt.Println("writing default templates to", args.writeTmpl)
+		return gowasm.WriteTemplates(args.writeTmpl)
+	}
+	templates, err := gowasm.LoadTemplates(args.templates)
+	if err != nil {
+		return err
+	}
+	var progress io.Writer = os.Stdout
+	if args.diff {
+		// keep stdout clean for the diff
+		progress = os.Stderr
+	}
+	if fi, err := os.Stat(args.outputPath); err != nil {
+		return fmt.Errorf("trouble evaluate %s: %s", args.outputPath, err)
+	} else if !fi.IsDir() {
+		return fmt.Errorf("output path '%s' doesn't point to a directory", args.outputPath)
+	}
+	p, err := loadAndTransform(progress)
+	if err != nil {
+		return err
+	}
+	trans, conv := p.trans, p.conv
+
+	target, _ := backend.Get(args.target)
+	if gen, ok := target.(*gowasm.Backend); ok {
+		gen.Log = progress
+		gen.Templates = templates
+	}
+	if args.sizeReport {
+		if err := printSizeReport(progress, target, conv); err != nil {
+			return err
+		}
+	}
+	files, err := target.WriteSource(conv)
+	if err != nil {
+		return err
+	}
+
+	folders := []string{}
+	outFiles := []*output.File{}
+	for _, src := range files {
+		filename, inc := src.Filename(args.insidePkg)
+		if !inc {
+			fmt.Fprintf(progress, "skipping '%s' as we are inside '%s'\n", src.Package, args.insidePkg)
+			continue
+		}
+		folders = append(folders, filepath.Dir(filepath.Join(args.outputPath, filename)))
+		outFiles = append(outFiles, &output.File{
+			Name:    filepath.ToSlash(filename),
+			Content: src.Content,
+		})
+	}
+	writer := &output.Writer{
+		Root:   args.outputPath,
+		Target: args.target,
+		Force:  args.force,
+		Log: func(format string, values ...interface{}) {
+			fmt.Fprintf(progress, format+"\n", values...)
+		},
+	}
+	if args.diff {
+		differ, err := writer.Diff(outFiles, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if differ {
+			return errors.New("generated output is not up to date")
+		}
+		return nil
+	}
+	result, err := writer.Write(outFiles)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d file(s) written, %d unchanged, %d removed\n",
+		len(result.Written), len(result.Unchanged), len(result.Removed))
+	for _, c := range result.Conflicts {
+		fmt.Fprintln(os.Stderr, "conflict:", c)
+	}
+	if args.statusFile != "" {
+		if err := trans.WriteMarkdownStatus(args.statusFile); err != nil {
+			return err
+		}
+	}
+	if args.crossRef != "" {
+		if err := trans.WriteCrossReference(args.crossRef); err != nil {
+			return err
+		}
+	}
+	if args.check {
+		if err := checkResult(target, files); err != nil {
+			return err
+		}
+	}
+	if err := tryCompileResult(folders); err != nil {
+		return err
+	}
+	if err := tryTestResult(folders); err != nil {
+		return err
+	}
+	if len(result.Conflicts) > 0 {
+		return fmt.Errorf("%d hand edited or unknown file(s) not updated, use -force to overwrite", len(result.Conflicts))
+	}
+	return nil
+}
+
+// sizeReporter is a backend that can report source code size
+type sizeReporter interface {
+	SizeReport(conv *types.Convert) ([]*gowasm.SizeStat, error)
+}
+
+func printSizeReport(dst io.Writer, target backend.Backend, conv *types.Convert) error {
+	reporter, ok := target.(sizeReporter)
+	if !ok {
+		return fmt.Errorf("-size-report is not supported by target '%s'", target.Name())
+	}
+	stats, err := reporter.SizeReport(conv)
+	if err != nil {
+		return err
+	}
+	var inline, shared int
+	fmt.Fprintf(dst, "%-40s %10s %10s %8s %10s\n", "package", "inline", "shared", "saving", "converters")
+	for _, s := range stats {
+		fmt.Fprintf(dst, "%-40s %10d %10d %7.1f%% %10d\n", s.Package, s.Inline, s.Shared,
+			sizeSaving(s.Inline, s.Shared), s.Converters)
+		fmt.Fprintf(dst, "%-40s %10d %10d %7.1f%%\n", "  (lines)", s.InlineLines, s.SharedLines,
+			sizeSaving(s.InlineLines, s.SharedLines))
+		inline += s.Inline
+		shared += s.Shared
+	}
+	fmt.Fprintf(dst, "%-40s %10d %10d %7.1f%%\n", "total", inline, shared, sizeSaving(inline, shared))
+	return nil
+}
+
+func sizeSaving(before, after int) float64 {
+	if before == 0 {
+		return 0
+	}
+	return 100 * float64(before-after) / float64(before)
+}
+
+// checkResult is type checking all generated source code
+func checkResult(target backend.Backend, files []*backend.Source) error {
+	gen, _ := target.(*gowasm.Backend)
+	list := []*gocheck.File{}
+	for _, src := range files {
+		filename, inc := src.Filename(args.insidePkg)
+		if !inc {
+			continue
+		}
+		path, err := filepath.Abs(filepath.Join(args.outputPath, filename))
+		if err != nil {
+			return err
+		}
+		file := &gocheck.File{
+			Package: src.Package,
+			Path:    path,
+			Content: src.Content,
+		}
+		if gen != nil {
+			file.Lines = gen.LineTable(src)
+		}
+		list = append(list, file)
+	}
+	fmt.Println("type checking generated source code")
+	errs, err := gocheck.Check(list)
+	if err != nil {
+		return err
+	}
+	gocheck.Print(os.Stderr, errs)
+	if len(errs) > 0 {
+		return fmt.Errorf("type checking found %d error(s)", len(errs))
+	}
+	return nil
+}
+
+func tryCompileResult(folders []string) error {
+	if args.goBuild == "" {
+		return nil
+	}
+	sort.Strings(folders)
+	last := ":/:"
+	failed := []string{}
+	for _, folder := range folders {
+		if folder == last {
+			continue
+		}
+		last = folder
+
+		wasm := args.goBuild == "wasm"
+		args := []string{"build"}
+
+		p := exec.Command("go", args...)
+		p.Dir = folder
+		p.Stdout = os.Stdout
+		p.Stderr = os.Stderr
+		if wasm {
+			p.Env = os.Environ()
+			p.Env = append(p.Env, "GOOS=js")
+			p.Env = append(p.Env, "GOARCH=wasm")
+		}
+		fmt.Printf("> running '%s' in folder %s\n", strings.Join(p.Args, " "), folder)
+		if err := p.Run(); err != nil {
+			fmt.Println("> error: command failed:", err)
+			failed = append(failed, folder)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("not all building was successful. failure in %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func tryTestResult(folders []string) error {
+	if args.goTest == "" {
+		return nil
+	}
+	sort.Strings(folders)
+	last := ":/:"
+	failed := []string{}
+	for _, folder := range folders {
+		if folder == last {
+			continue
+		}
+		last = folder
+
+		// any test files?
+		if yes, err := haveTestFiles(folder); err != nil {
+			return err
+		} else if !yes {
+			continue
+		}
+
+		wasm := args.goTest == "wasm"
+		args := []string{"test"}
+		// if !wasm {
+		// 	args = append(args, "-i")
+		// }
+
+		p := exec.Command("go", args...)
+		p.Dir = folder
+		p.Stdout = os.Stdout
+		p.Stderr = os.Stderr
+		if wasm {
+			p.Env = os.Environ()
+			p.Env = append(p.Env, "GOOS=js")
+			p.Env = append(p.Env, "GOARCH=wasm")
+		}
+		fmt.Printf("> running '%s' in folder %s\n", strings.Join(p.Args, " "), folder)
+		if err := p.Run(); err != nil {
+			fmt.Println("> error: command failed:", err)
+			failed = append(failed, folder)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("not all test was successful. failure in %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func haveTestFiles(path string) (bool, error) {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), "_test.go") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,298 @@
+// Package gocheck is type checking generated source code in-process
+// with go/types, for both GOOS=js and host builds. Errors are mapped
+// back to the generated declaration and the WebIDL definition.
+package gocheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// File is a generated source file
+type File struct {
+	// Package is the import path
+	Package string
+
+	// Path is the filename on disk. It is used to resolve imports
+	// from outside of generated packages.
+	Path string
+
+	Content []byte
+
+	// Lines is the declarations in the file, errors are mapped to
+	// the WebIDL definition with it. Can be nil.
+	Lines *gowasm.LineTable
+}
+
+// Error is a single type checking error
+type Error struct {
+	// Build is "js" or "host"
+	Build string
+
+	Pos token.Position
+	Msg string
+
+	// Decl is the generated declaration, e.g. Foo.SetBar
+	Decl string
+
+	// Ref is the WebIDL source of the declaration, can be nil
+	Ref *types.Ref
+}
+
+func (e *Error) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s: %s (%s build)", e.Pos, e.Msg, e.Build)
+	if e.Decl != "" {
+		fmt.Fprintf(&out, "\n\tin declaration %s", e.Decl)
+	}
+	if e.Ref != nil {
+		fmt.Fprintf(&out, "\n\tdefined at %s", e.Ref)
+		if e.Ref.RenamedBy != "" {
+			fmt.Fprintf(&out, "\n\trenamed by %s", e.Ref.RenamedBy)
+		}
+	}
+	return out.String()
+}
+
+// Check is type checking all files for both js and host builds
+func Check(files []*File) ([]*Error, error) {
+	var ret []*Error
+	for _, goos := range []string{"js", "host"} {
+		list, err := checkBuild(goos, files)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, list...)
+	}
+	return ret, nil
+}
+
+// Print is writing all errors
+func Print(dst io.Writer, list []*Error) {
+	for _, e := range list {
+		fmt.Fprintln(dst, "error:", e)
+	}
+}
+
+// checker is a single build of all generated packages
+type checker struct {
+	build    string
+	ctx      build.Context
+	fset     *token.FileSet
+	sources  map[string][]*File
+	packages map[string]*gotypes.Package
+	fallback gotypes.ImporterFrom
+	tables   map[string]*gowasm.LineTable
+	errors   []*Error
+}
+
+func checkBuild(goos string, files []*File) ([]*Error, error) {
+	c := &checker{
+		build:    goos,
+		ctx:      build.Default,
+		fset:     token.NewFileSet(),
+		sources:  make(map[string][]*File),
+		packages: make(map[string]*gotypes.Package),
+		tables:   make(map[string]*gowasm.LineTable),
+	}
+	env := os.Environ()
+	if goos == "js" {
+		c.ctx.GOOS = "js"
+		c.ctx.GOARCH = "wasm"
+		c.ctx.CgoEnabled = false
+		env = append(env, "GOOS=js", "GOARCH=wasm")
+	}
+	byPath := make(map[string]*File)
+	for _, f := range files {
+		c.sources[f.Package] = append(c.sources[f.Package], f)
+		byPath[filepath.Clean(f.Path)] = f
+	}
+	// file matching is using in memory content
+	c.ctx.OpenFile = func(path string) (io.ReadCloser, error) {
+		if f, found := byPath[filepath.Clean(path)]; found {
+			return ioutil.NopCloser(strings.NewReader(string(f.Content))), nil
+		}
+		return nil, fmt.Errorf("%s: file not found", path)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	exports, err := c.exportData(existingDir(files[0].Path), env)
+	if err != nil {
+		return nil, err
+	}
+	c.fallback = importer.ForCompiler(c.fset, "gc", func(path string) (io.ReadCloser, error) {
+		if file, found := exports[path]; found && file != "" {
+			return os.Open(file)
+		}
+		return nil, fmt.Errorf("no export data for %s", path)
+	}).(gotypes.ImporterFrom)
+
+	pkgs := make([]string, 0, len(c.sources))
+	for pkg := range c.sources {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		if _, err := c.checkPackage(pkg); err != nil {
+			return nil, err
+		}
+	}
+	return c.errors, nil
+}
+
+// exportData is compiling all packages imported by generated source
+// code, that isn't generated, with go list and return the location
+// of the export data files
+func (c *checker) exportData(dir string, env []string) (map[string]string, error) {
+	imports := make(map[string]bool)
+	for pkg, list := range c.sources {
+		for _, f := range list {
+			file, err := parser.ParseFile(token.NewFileSet(), f.Path, f.Content, parser.ImportsOnly)
+			if err != nil {
+				// reported when checking the package
+				continue
+			}
+			for _, spec := range file.Imports {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				if _, generated := c.sources[path]; !generated && path != pkg {
+					imports[path] = true
+				}
+			}
+		}
+	}
+	ret := make(map[string]string)
+	if len(imports) == 0 {
+		return ret, nil
+	}
+	args := []string{"list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", "--"}
+	for path := range imports {
+		args = append(args, path)
+	}
+	var stdout, stderr bytes.Buffer
+	p := exec.Command("go", args...)
+	p.Dir = dir
+	p.Env = env
+	p.Stdout = &stdout
+	p.Stderr = &stderr
+	if err := p.Run(); err != nil {
+		return nil, fmt.Errorf("go list in %s failed: %s\n%s", dir, err, stderr.String())
+	}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if idx := strings.Index(line, "\t"); idx != -1 {
+			ret[line[:idx]] = line[idx+1:]
+		}
+	}
+	return ret, nil
+}
+
+// existingDir is the nearest existing folder of a file
+func existingDir(path string) string {
+	dir := filepath.Dir(path)
+	for {
+		if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+func (c *checker) Import(path string) (*gotypes.Package, error) {
+	return c.ImportFrom(path, "", 0)
+}
+
+func (c *checker) ImportFrom(path, dir string, mode gotypes.ImportMode) (*gotypes.Package, error) {
+	if _, found := c.sources[path]; found {
+		return c.checkPackage(path)
+	}
+	return c.fallback.ImportFrom(path, dir, mode)
+}
+
+// checkPackage is type checking a generated package once
+func (c *checker) checkPackage(pkg string) (*gotypes.Package, error) {
+	if done, found := c.packages[pkg]; found {
+		if done == nil {
+			return nil, fmt.Errorf("import cycle in %s", pkg)
+		}
+		return done, nil
+	}
+	c.packages[pkg] = nil
+	var parsed []*ast.File
+	for _, f := range c.sources[pkg] {
+		if !strings.HasSuffix(f.Path, ".go") || strings.HasSuffix(f.Path, "_test.go") {
+			continue
+		}
+		dir, name := filepath.Split(f.Path)
+		if match, err := c.ctx.MatchFile(dir, name); err != nil {
+			return nil, err
+		} else if !match {
+			continue
+		}
+		file, err := parser.ParseFile(c.fset, f.Path, f.Content, parser.ParseComments)
+		if err != nil {
+			c.addError(f.Path, err.Error(), token.Position{Filename: f.Path})
+			continue
+		}
+		if f.Lines != nil {
+			c.tables[f.Path] = f.Lines
+		}
+		parsed = append(parsed, file)
+	}
+	conf := gotypes.Config{
+		Importer: c,
+		Error: func(err error) {
+			if te, ok := err.(gotypes.Error); ok {
+				pos := te.Fset.PositionFor(te.Pos, false)
+				c.addError(pos.Filename, te.Msg, pos)
+			} else {
+				c.addError("", err.Error(), token.Position{})
+			}
+		},
+	}
+	name := pkg
+	if idx := strings.LastIndex(name, "/"); idx != -1 {
+		name = name[idx+1:]
+	}
+	result, _ := conf.Check(pkg, c.fset, parsed, nil)
+	if result == nil {
+		result = gotypes.NewPackage(pkg, name)
+	}
+	c.packages[pkg] = result
+	return result, nil
+}
+
+func (c *checker) addError(filename, msg string, pos token.Position) {
+	e := &Error{
+		Build: c.build,
+		Pos:   pos,
+		Msg:   msg,
+	}
+	if table, found := c.tables[filename]; found {
+		if entry := table.Lookup(pos.Line); entry != nil {
+			e.Decl = entry.Decl
+			e.Ref = entry.Ref
+		}
+	}
+	c.errors = append(c.errors, e)
+}
@@ -0,0 +1,132 @@
+package gocheck
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+const testIdl = `
+interface Foo {
+	attribute long bar;
+	void hello(DOMString name);
+};
+`
+
+const testWasm = `package check
+
+import "syscall/js"
+
+type Foo struct {
+	Value_JS js.Value
+}
+
+func (_this *Foo) Bar() int {
+	return _this.Value_JS.Get("bar").Int()
+}
+
+func (_this *Foo) SetBar(value int) {
+	_this.Value_JS.Set("bar", missingValue)
+}
+`
+
+const testHost = `// +build !js
+
+package check
+
+type Foo struct {
+}
+
+func (_this *Foo) Hello(name string) int {
+	return name
+}
+`
+
+func loadConvert(t *testing.T) *types.Convert {
+	conv := types.NewConvert()
+	setup := &types.Setup{
+		Error: func(ref types.GetRef, format string, args ...interface{}) {
+			t.Errorf(format, args...)
+		},
+		Warning:  func(ref types.GetRef, format string, args ...interface{}) {},
+		Filename: "check.idl",
+		Package:  "check",
+	}
+	if !assert.Nil(t, conv.Parse([]byte(testIdl), setup)) || !assert.Nil(t, conv.Evaluate()) {
+		t.FailNow()
+	}
+	return conv
+}
+
+func TestCheckErrors(t *testing.T) {
+	conv := loadConvert(t)
+	foo := conv.Types["Foo"].(*types.Interface)
+	foo.Method[0].SourceReference().RenamedBy = "check.md:4"
+	dir, _ := filepath.Abs("testdata")
+	jsLines := &gowasm.LineTable{Entries: []*gowasm.LineEntry{
+		{From: 5, To: 7, Decl: "Foo", Ref: foo.SourceReference()},
+		{From: 9, To: 11, Decl: "Foo.Bar", Ref: foo.Vars[0].SourceReference()},
+		{From: 13, To: 15, Decl: "Foo.SetBar", Ref: foo.Vars[0].SourceReference()},
+	}}
+	hostLines := &gowasm.LineTable{Entries: []*gowasm.LineEntry{
+		{From: 5, To: 6, Decl: "Foo", Ref: foo.SourceReference()},
+		{From: 8, To: 10, Decl: "Foo.Hello", Ref: foo.Method[0].SourceReference()},
+	}}
+	files := []*File{
+		{Package: "check", Path: filepath.Join(dir, "check_js.go"), Content: []byte(testWasm), Lines: jsLines},
+		{Package: "check", Path: filepath.Join(dir, "check.go"), Content: []byte(testHost), Lines: hostLines},
+	}
+	list, err := Check(files)
+	if !assert.Nil(t, err) || !assert.Equal(t, 2, len(list)) {
+		for _, e := range list {
+			t.Log(e)
+		}
+		return
+	}
+
+	js := list[0]
+	assert.Equal(t, "js", js.Build)
+	assert.Equal(t, 14, js.Pos.Line)
+	assert.Equal(t, "undefined: missingValue", js.Msg)
+	assert.Equal(t, "Foo.SetBar", js.Decl)
+	if assert.NotNil(t, js.Ref) {
+		assert.Equal(t, 3, js.Ref.Line)
+	}
+
+	host := list[1]
+	assert.Equal(t, "host", host.Build)
+	assert.Equal(t, 9, host.Pos.Line)
+	assert.Equal(t, "Foo.Hello", host.Decl)
+	if assert.NotNil(t, host.Ref) {
+		assert.Equal(t, 4, host.Ref.Line)
+		assert.Contains(t, host.String(), "renamed by check.md:4")
+	}
+}
+
+func TestCheckGenerated(t *testing.T) {
+	conv := loadConvert(t)
+	gen := gowasm.NewBackend(&gowasm.Options{})
+	src, err := gen.WriteSource(conv)
+	if !assert.Nil(t, err) {
+		return
+	}
+	dir, _ := filepath.Abs("testdata")
+	files := []*File{}
+	for _, s := range src {
+		files = append(files, &File{
+			Package: s.Package,
+			Path:    filepath.Join(dir, s.Name),
+			Content: s.Content,
+			Lines:   gen.LineTable(s),
+		})
+	}
+	list, err := Check(files)
+	assert.Nil(t, err)
+	for _, e := range list {
+		t.Error(e)
+	}
+}
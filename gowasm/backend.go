@@ -0,0 +1,61 @@
+package gowasm
+
+import (
+	"io"
+	"os"
+
+	"github.com/gowebapi/webidl-bind/backend"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// Backend is the Go WebAssembly output backend. It's keeping state
+// while writing source code and can't be used concurrently.
+type Backend struct {
+	// Options is used when writing source code
+	Options *Options
+
+	// Templates is used when writing source code, nil is the
+	// default templates. tmpl is the templates of current
+	// WriteSource call
+	Templates *Templates
+	tmpl      *Templates
+
+	pkgMgr packageManager
+
+	// options used for current package and baseOptions is options
+	// given to WriteSource
+	options, baseOptions Options
+
+	// refs is the source reference of every marker and tables is
+	// the declarations in every written file
+	refs   []*types.Ref
+	tables map[string]*LineTable
+
+	// Log is receiving progress messages
+	Log io.Writer
+}
+
+var _ backend.Backend = &Backend{}
+
+// NewBackend is creating a backend that is writing source
+// code with given options. Options are read on every call to
+// WriteSource and can be changed after creation.
+func NewBackend(opts *Options) *Backend {
+	return &Backend{Options: opts, Log: os.Stdout}
+}
+
+// Name is returning 'gowasm'
+func (b *Backend) Name() string {
+	return "gowasm"
+}
+
+// Description is a single line help text
+func (b *Backend) Description() string {
+	return "Go bindings using syscall/js for GOOS=js GOARCH=wasm"
+}
+
+// WriteSource is creating source code files with a backend that
+// is using given options
+func WriteSource(conv *types.Convert, opts Options) ([]*backend.Source, error) {
+	return NewBackend(&opts).WriteSource(conv)
+}
@@ -2,6 +2,7 @@ package gowasm
 
 import (
 	"io"
+	"strings"
 	"text/template"
 
 	"github.com/gowebapi/webidl-bind/types"
@@ -55,6 +56,32 @@ func {{.Type.Def}}FromJS(_value js.Value) {{.Type.Output}} {
 		return
 	}
 }
+
+{{if .Scope}}
+// {{.Type.Def}}ToJSOnce is allocating a javascript function that
+// is released after the first invocation.
+func {{.Type.Def}}ToJSOnce(callback {{.Type.Def}}Func ) * {{.Type.Def}} {
+	if callback == nil {
+		return nil
+	}
+	var ret * {{.Type.Def}}
+	ret = {{.Type.Def}}ToJS(func ({{.ParamLine}}) {{.Return.Input}} {
+		defer js.Func(*ret).Release()
+		{{if not .VoidRet}}return {{end}} callback({{.ParamNames}})
+	})
+	return ret
+}
+
+// {{.Type.Def}}ToJSScope is allocating a javascript function that
+// is released together with scope.
+func {{.Type.Def}}ToJSScope(scope FuncTracker, callback {{.Type.Def}}Func ) * {{.Type.Def}} {
+	ret := {{.Type.Def}}ToJS(callback)
+	if ret != nil {
+		scope.Track(js.Func(*ret))
+	}
+	return ret
+}
+{{end}}
 {{end}}
 `
 
@@ -68,51 +95,63 @@ type callbackData struct {
 	ParamLine string
 	InOut     *inoutData
 	ArgVar    string
+
+	// ParamNames is all parameter names, separated by comma
+	ParamNames string
+
+	// Scope is true when release helpers should be created
+	Scope bool
 }
 
-func writeCallback(dst io.Writer, value types.Type) error {
+func (b *Backend) writeCallback(dst io.Writer, value types.Type) error {
 	cb := value.(*types.Callback)
 	data := &callbackData{
 		InOut:   setupInOutWasmData(cb.Parameters, "args[%d@variadicSlice@]", "_p%d", useOut),
 		VoidRet: types.IsVoid(cb.Return),
+		Scope:   b.options.CallbackScope,
 	}
 	data.ArgVar = calculateMethodArgsSize(data.InOut)
 	data.Return, _ = cb.Return.DefaultParam()
 	data.Type, _ = cb.DefaultParam()
 	data.ParamLine, data.Params = parameterArgumentLine(cb.Parameters)
-	if err := callbackTempl.ExecuteTemplate(dst, "start", data); err != nil {
+	names := make([]string, len(cb.Parameters))
+	for i, p := range cb.Parameters {
+		names[i] = p.Name
+	}
+	data.ParamNames = strings.Join(names, ", ")
+	if err := b.tmpl.callback.ExecuteTemplate(dst, "start", data); err != nil {
 		return err
 	}
-	if err := writeInOutFromWasm(data.InOut, "", useOut, dst); err != nil {
+	if err := b.writeInOutFromWasm(data.InOut, "", useOut, dst); err != nil {
 		return err
 	}
-	if err := callbackTempl.ExecuteTemplate(dst, "middle-1", data); err != nil {
+	if err := b.tmpl.callback.ExecuteTemplate(dst, "middle-1", data); err != nil {
 		return err
 	}
 	if !data.VoidRet {
 		result := setupInOutWasmForType(cb.Return, "", "_returned", "_converted", useOut)
-		if err := writeInOutToWasm(result, "", useOut, dst); err != nil {
+		if err := b.writeInOutToWasm(result, "", useOut, dst); err != nil {
 			return err
 		}
 	}
-	if err := callbackTempl.ExecuteTemplate(dst, "middle-2", data); err != nil {
+	if err := b.tmpl.callback.ExecuteTemplate(dst, "middle-2", data); err != nil {
 		return err
 	}
 	fromjs := setupInOutWasmData(cb.Parameters, "@name@", "_p%d", useOut)
 	assign := "_args[%d] = _p%d; _end++"
-	if err := writeInOutToWasm(fromjs, assign, useOut, dst); err != nil {
+	if err := b.writeInOutToWasm(fromjs, assign, useOut, dst); err != nil {
 		return err
 	}
-	if err := callbackTempl.ExecuteTemplate(dst, "invoke", data); err != nil {
+	if err := b.tmpl.callback.ExecuteTemplate(dst, "invoke", data); err != nil {
 		return err
 	}
 	if !data.VoidRet {
 		result := setupInOutWasmForType(cb.Return, "", "_returned", "_converted", useOut)
-		if err := writeInOutFromWasm(result, "", useOut, dst); err != nil {
+		if err := b.writeInOutFromWasm(result, "", useOut, dst); err != nil {
 			return err
 		}
 	}
-	if err := callbackTempl.ExecuteTemplate(dst, "end", data); err != nil {
+	if err := b.tmpl.callback.ExecuteTemplate(dst, "end", data); err != nil {
 		return err
 	}
 	return nil
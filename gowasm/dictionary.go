@@ -59,7 +59,7 @@ type dictionaryMember struct {
 	toIn, toOut     string
 }
 
-func writeDictionary(dst io.Writer, value types.Type) error {
+func (b *Backend) writeDictionary(dst io.Writer, value types.Type) error {
 	dict := value.(*types.Dictionary)
 	data := &dictionaryData{
 		Dict: dict,
@@ -80,21 +80,21 @@ func writeDictionary(dst io.Writer, value types.Type) error {
 		}
 		mo.fromIn, mo.fromOut = setupVarName("value.Get(\"@name@\")", idx, mo.Name.Idl, false), setupVarName("value%d", idx, mo.Name.Def, false)
 		mo.toIn, mo.toOut = setupVarName("_this.@name@", idx, mo.Name.Def, false), setupVarName("value%d", idx, mo.Name.Def, false)
-		from.WriteString(inoutParamStart(mo.Ref, mo.Type, mo.fromOut, mo.fromIn, idx, useOut, inoutFromTmpl))
-		from.WriteString(inoutGetToFromWasm(mo.Ref, mo.Type, mo.fromOut, mo.fromIn, idx, useOut, inoutFromTmpl))
-		from.WriteString(inoutParamEnd(mo.Type, "", inoutFromTmpl))
+		from.WriteString(inoutParamStart(mo.Ref, mo.Type, mo.fromOut, mo.fromIn, idx, useOut, b.tmpl.inoutFrom))
+		from.WriteString(b.inoutGetToFromWasm(mo.Ref, mo.Type, mo.fromOut, mo.fromIn, idx, useOut, b.tmpl.inoutFrom))
+		from.WriteString(inoutParamEnd(mo.Type, "", b.tmpl.inoutFrom))
 		from.WriteString(fmt.Sprintf("\n\tout.%s = value%d\n", mo.Name.Def, idx))
-		to.WriteString(inoutParamStart(mo.Ref, mo.Type, mo.toOut, mo.toIn, idx, useOut, inoutToTmpl))
-		to.WriteString(inoutGetToFromWasm(mo.Ref, mo.Type, mo.toOut, mo.toIn, idx, useOut, inoutToTmpl))
-		to.WriteString(inoutParamEnd(mo.Type, "", inoutToTmpl))
+		to.WriteString(inoutParamStart(mo.Ref, mo.Type, mo.toOut, mo.toIn, idx, useOut, b.tmpl.inoutTo))
+		to.WriteString(b.inoutGetToFromWasm(mo.Ref, mo.Type, mo.toOut, mo.toIn, idx, useOut, b.tmpl.inoutTo))
+		to.WriteString(inoutParamEnd(mo.Type, "", b.tmpl.inoutTo))
 		to.WriteString(fmt.Sprintf("\n\tout.Set(\"%s\", value%d)\n", mi.Name().Idl, idx))
 	}
-	varFrom := inoutDictionaryVariableStart(data, useOut, inoutFromTmpl)
-	varTo := inoutDictionaryVariableStart(data, useOut, inoutToTmpl)
+	varFrom := inoutDictionaryVariableStart(data, useOut, b.tmpl.inoutFrom)
+	varTo := inoutDictionaryVariableStart(data, useOut, b.tmpl.inoutTo)
 	data.ReqParamLine = strings.Join(reqParam, ", ")
 	data.From, data.To = varFrom+from.String(), varTo+to.String()
 
-	if err := dictionaryTmpl.ExecuteTemplate(dst, "header", data); err != nil {
+	if err := b.tmpl.dictionary.ExecuteTemplate(dst, "header", data); err != nil {
 		return err
 	}
 	return nil
@@ -57,7 +57,7 @@ func {{.Basic.Def}}FromJS(value js.Value) {{.DefaultParam.Output}} {
 
 var enumTempl = template.Must(template.New("enum").Parse(enumTmplInput))
 
-func writeEnum(dst io.Writer, e types.Type) error {
+func (b *Backend) writeEnum(dst io.Writer, e types.Type) error {
 	data := struct {
 		Basic        types.BasicInfo
 		Enum         types.Type
@@ -67,5 +67,5 @@ func writeEnum(dst io.Writer, e types.Type) error {
 	}
 	data.DefaultParam, _ = e.DefaultParam()
 	data.Basic = data.DefaultParam.BasicInfo
-	return enumTempl.ExecuteTemplate(dst, "header", data)
+	return b.tmpl.enum.ExecuteTemplate(dst, "header", data)
 }
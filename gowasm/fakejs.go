@@ -0,0 +1,701 @@
+package gowasm
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/gowebapi/webidl-bind/backend"
+)
+
+// fake javascript runtime that can replace syscall/js, core and
+// jsarray in host builds. it's used to unit test generated code
+// without a browser.
+const fakeJSTmplInput = `
+{{define "fakejs"}}
+// Code generated by webidl-bind. DO NOT EDIT.
+
+// Package {{.Package}} is a programmable fake javascript runtime
+// with the same api as syscall/js. It is replacing syscall/js,
+// core and jsarray packages in host builds of generated code.
+//
+// Tests are setting up javascript objects and functions, invoke
+// generated code and then inspect recorded calls and property sets.
+package {{.Package}}
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// Type represents the JavaScript type of a Value.
+type Type int
+
+const (
+	TypeUndefined Type = iota
+	TypeNull
+	TypeBoolean
+	TypeNumber
+	TypeString
+	TypeSymbol
+	TypeObject
+	TypeFunction
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeUndefined:
+		return "undefined"
+	case TypeNull:
+		return "null"
+	case TypeBoolean:
+		return "boolean"
+	case TypeNumber:
+		return "number"
+	case TypeString:
+		return "string"
+	case TypeSymbol:
+		return "symbol"
+	case TypeObject:
+		return "object"
+	case TypeFunction:
+		return "function"
+	default:
+		panic("bad type")
+	}
+}
+
+// Value represents a JavaScript value. The zero value is the JavaScript value "undefined".
+type Value struct {
+	ref *object
+}
+
+type object struct {
+	typ      Type
+	value    interface{}
+	props    map[string]Value
+	items    []Value
+	fn       func(this Value, args []Value) interface{}
+	released bool
+	ctor     *object
+}
+
+// Wrapper is implemented by types that are backed by a JavaScript value.
+type Wrapper interface {
+	// JSValue returns a JavaScript value associated with an object.
+	JSValue() Value
+}
+
+// Error wraps a JavaScript error.
+type Error struct {
+	// Value is the underlying JavaScript error value.
+	Value
+}
+
+// Error implements the error interface.
+func (e Error) Error() string {
+	return "JavaScript error: " + e.Get("message").String()
+}
+
+// A ValueError occurs when a Value method is invoked on
+// a Value that does not support it.
+type ValueError struct {
+	Method string
+	Type   Type
+}
+
+func (e *ValueError) Error() string {
+	return "syscall/js: call of " + e.Method + " on " + e.Type.String()
+}
+
+// Func is a wrapped Go function to be called by JavaScript.
+type Func struct {
+	Value // the JavaScript function that invokes the Go function
+}
+
+// CallRecord is a recorded method call, function invocation
+// or constructor call.
+type CallRecord struct {
+	// This is the object the method is called on. For function
+	// invocations and constructor calls it is the function itself.
+	This Value
+
+	// Method is the method name, "" for invoke and "new" for a
+	// constructor call
+	Method string
+
+	// Args is the arguments, converted by ValueOf
+	Args []Value
+}
+
+// SetRecord is a recorded property assignment.
+type SetRecord struct {
+	This     Value
+	Property string
+	Value    Value
+}
+
+var runtime struct {
+	lock   sync.Mutex
+	global Value
+	calls  []CallRecord
+	sets   []SetRecord
+	funcs  map[*object]struct{}
+}
+
+func init() {
+	Reset()
+}
+
+// Reset is creating a new global object and clear all recorded
+// calls, property sets and allocated functions.
+func Reset() {
+	global := NewObject()
+	global.ref.props["Object"] = NewFunction(func(this Value, args []Value) interface{} {
+		return nil
+	})
+	global.ref.props["Array"] = NewFunction(func(this Value, args []Value) interface{} {
+		if len(args) == 1 && args[0].Type() == TypeNumber {
+			return newArray(make([]Value, args[0].Int()))
+		}
+		return newArray(append([]Value{}, args...))
+	})
+	global.ref.props["Error"] = NewFunction(func(this Value, args []Value) interface{} {
+		return nil
+	})
+	runtime.lock.Lock()
+	defer runtime.lock.Unlock()
+	runtime.global = global
+	runtime.calls = nil
+	runtime.sets = nil
+	runtime.funcs = make(map[*object]struct{})
+}
+
+// Calls is returning all recorded calls since last Reset.
+func Calls() []CallRecord {
+	runtime.lock.Lock()
+	defer runtime.lock.Unlock()
+	return append([]CallRecord{}, runtime.calls...)
+}
+
+// CallsTo is returning all recorded calls to method name.
+func CallsTo(method string) []CallRecord {
+	var ret []CallRecord
+	for _, c := range Calls() {
+		if c.Method == method {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Sets is returning all recorded property sets since last Reset.
+func Sets() []SetRecord {
+	runtime.lock.Lock()
+	defer runtime.lock.Unlock()
+	return append([]SetRecord{}, runtime.sets...)
+}
+
+// FuncCount is returning number of allocated functions that
+// isn't released.
+func FuncCount() int {
+	runtime.lock.Lock()
+	defer runtime.lock.Unlock()
+	return len(runtime.funcs)
+}
+
+// NewObject is allocating a new, empty, javascript object.
+func NewObject() Value {
+	return Value{ref: &object{typ: TypeObject, props: make(map[string]Value)}}
+}
+
+// NewFunction is allocating a programmable javascript function. The
+// return value is converted with ValueOf. Use Throw to raise an exception.
+func NewFunction(fn func(this Value, args []Value) interface{}) Value {
+	return Value{ref: &object{typ: TypeFunction, props: make(map[string]Value), fn: fn}}
+}
+
+// NewError is allocating a javascript Error object.
+func NewError(message string) Value {
+	e := NewObject()
+	e.ref.props["message"] = ValueOf(message)
+	e.ref.ctor = Global().Get("Error").ref
+	return e
+}
+
+// Throw is raising a javascript exception. It should be called
+// from a function created with NewFunction.
+func Throw(message string) {
+	panic(Error{Value: NewError(message)})
+}
+
+func newArray(items []Value) Value {
+	return Value{ref: &object{typ: TypeObject, props: make(map[string]Value), items: items}}
+}
+
+func record(this Value, method string, args []Value) {
+	runtime.lock.Lock()
+	runtime.calls = append(runtime.calls, CallRecord{This: this, Method: method, Args: args})
+	runtime.lock.Unlock()
+}
+
+func typeError(format string, args ...interface{}) {
+	Throw("TypeError: " + fmt.Sprintf(format, args...))
+}
+
+// Global returns the JavaScript global object.
+func Global() Value {
+	runtime.lock.Lock()
+	defer runtime.lock.Unlock()
+	return runtime.global
+}
+
+var (
+	null      = Value{ref: &object{typ: TypeNull}}
+	boolTrue  = Value{ref: &object{typ: TypeBoolean, value: true}}
+	boolFalse = Value{ref: &object{typ: TypeBoolean, value: false}}
+)
+
+// Null returns the JavaScript value "null".
+func Null() Value {
+	return null
+}
+
+// Undefined returns the JavaScript value "undefined".
+func Undefined() Value {
+	return Value{}
+}
+
+// ValueOf returns x as a JavaScript value:
+//
+//  | Go                     | JavaScript             |
+//  | ---------------------- | ---------------------- |
+//  | js.Value               | [its value]            |
+//  | js.Func                | function               |
+//  | nil                    | null                   |
+//  | bool                   | boolean                |
+//  | integers and floats    | number                 |
+//  | string                 | string                 |
+//  | []interface{}          | new array              |
+//  | map[string]interface{} | new object             |
+//
+// Panics if x is not one of the expected types.
+func ValueOf(x interface{}) Value {
+	switch x := x.(type) {
+	case Value:
+		return x
+	case Func:
+		return x.Value
+	case nil:
+		return null
+	case bool:
+		if x {
+			return boolTrue
+		}
+		return boolFalse
+	case int:
+		return number(float64(x))
+	case int8:
+		return number(float64(x))
+	case int16:
+		return number(float64(x))
+	case int32:
+		return number(float64(x))
+	case int64:
+		return number(float64(x))
+	case uint:
+		return number(float64(x))
+	case uint8:
+		return number(float64(x))
+	case uint16:
+		return number(float64(x))
+	case uint32:
+		return number(float64(x))
+	case uint64:
+		return number(float64(x))
+	case uintptr:
+		return number(float64(x))
+	case float32:
+		return number(float64(x))
+	case float64:
+		return number(x)
+	case string:
+		return Value{ref: &object{typ: TypeString, value: x}}
+	case []interface{}:
+		items := make([]Value, len(x))
+		for i, v := range x {
+			items[i] = ValueOf(v)
+		}
+		return newArray(items)
+	case map[string]interface{}:
+		o := NewObject()
+		for k, v := range x {
+			o.ref.props[k] = ValueOf(v)
+		}
+		return o
+	default:
+		panic("ValueOf: invalid value")
+	}
+}
+
+func number(x float64) Value {
+	return Value{ref: &object{typ: TypeNumber, value: x}}
+}
+
+func valuesOf(args []interface{}) []Value {
+	ret := make([]Value, len(args))
+	for i, a := range args {
+		ret[i] = ValueOf(a)
+	}
+	return ret
+}
+
+// Type returns the JavaScript type of the value v.
+func (v Value) Type() Type {
+	if v.ref == nil {
+		return TypeUndefined
+	}
+	return v.ref.typ
+}
+
+func (v Value) isObject() bool {
+	t := v.Type()
+	return t == TypeObject || t == TypeFunction
+}
+
+// Equal reports whether v and w are equal according to JavaScript's === operator.
+func (v Value) Equal(w Value) bool {
+	if v.ref == w.ref {
+		return true
+	}
+	if v.Type() != w.Type() || v.isObject() {
+		return false
+	}
+	return v.ref.value == w.ref.value
+}
+
+// IsUndefined reports whether v is the JavaScript value "undefined".
+func (v Value) IsUndefined() bool {
+	return v.Type() == TypeUndefined
+}
+
+// IsNull reports whether v is the JavaScript value "null".
+func (v Value) IsNull() bool {
+	return v.Type() == TypeNull
+}
+
+// IsNaN reports whether v is the JavaScript value "NaN".
+func (v Value) IsNaN() bool {
+	return v.Type() == TypeNumber && math.IsNaN(v.ref.value.(float64))
+}
+
+// JSValue implements Wrapper interface.
+func (v Value) JSValue() Value {
+	return v
+}
+
+// Bool returns the value v as a bool. It panics if v is not a JavaScript boolean.
+func (v Value) Bool() bool {
+	if v.Type() != TypeBoolean {
+		panic(&ValueError{"Value.Bool", v.Type()})
+	}
+	return v.ref.value.(bool)
+}
+
+// Float returns the value v as a float64. It panics if v is not a JavaScript number.
+func (v Value) Float() float64 {
+	if v.Type() != TypeNumber {
+		panic(&ValueError{"Value.Float", v.Type()})
+	}
+	return v.ref.value.(float64)
+}
+
+// Int returns the value v truncated to an int. It panics if v is not a JavaScript number.
+func (v Value) Int() int {
+	if v.Type() != TypeNumber {
+		panic(&ValueError{"Value.Int", v.Type()})
+	}
+	return int(v.ref.value.(float64))
+}
+
+// String returns the value v as a string. It does not panic if v's Type is
+// not TypeString. Instead, it returns a string of the form "<T>" or "<T: V>".
+func (v Value) String() string {
+	switch v.Type() {
+	case TypeString:
+		return v.ref.value.(string)
+	case TypeUndefined, TypeNull, TypeObject, TypeFunction, TypeSymbol:
+		return "<" + v.Type().String() + ">"
+	case TypeBoolean:
+		return "<boolean: " + strconv.FormatBool(v.Bool()) + ">"
+	case TypeNumber:
+		return "<number: " + strconv.FormatFloat(v.Float(), 'g', -1, 64) + ">"
+	}
+	panic("bad type")
+}
+
+// Truthy returns the JavaScript "truthiness" of the value v.
+func (v Value) Truthy() bool {
+	switch v.Type() {
+	case TypeUndefined, TypeNull:
+		return false
+	case TypeBoolean:
+		return v.Bool()
+	case TypeNumber:
+		f := v.Float()
+		return f != 0 && !math.IsNaN(f)
+	case TypeString:
+		return v.String() != ""
+	}
+	return true
+}
+
+// Get returns the JavaScript property p of value v.
+// It panics if v is not a JavaScript object.
+func (v Value) Get(p string) Value {
+	if !v.isObject() {
+		panic(&ValueError{"Value.Get", v.Type()})
+	}
+	if p == "length" && v.ref.items != nil {
+		return number(float64(len(v.ref.items)))
+	}
+	return v.ref.props[p]
+}
+
+// Set sets the JavaScript property p of value v to ValueOf(x).
+// It panics if v is not a JavaScript object.
+func (v Value) Set(p string, x interface{}) {
+	if !v.isObject() {
+		panic(&ValueError{"Value.Set", v.Type()})
+	}
+	value := ValueOf(x)
+	v.ref.props[p] = value
+	runtime.lock.Lock()
+	runtime.sets = append(runtime.sets, SetRecord{This: v, Property: p, Value: value})
+	runtime.lock.Unlock()
+}
+
+// Delete deletes the JavaScript property p of value v.
+// It panics if v is not a JavaScript object.
+func (v Value) Delete(p string) {
+	if !v.isObject() {
+		panic(&ValueError{"Value.Delete", v.Type()})
+	}
+	delete(v.ref.props, p)
+}
+
+// Index returns JavaScript index i of value v.
+// It panics if v is not a JavaScript object.
+func (v Value) Index(i int) Value {
+	if !v.isObject() {
+		panic(&ValueError{"Value.Index", v.Type()})
+	}
+	if i < 0 || i >= len(v.ref.items) {
+		return Value{}
+	}
+	return v.ref.items[i]
+}
+
+// SetIndex sets the JavaScript index i of value v to ValueOf(x).
+// It panics if v is not a JavaScript object.
+func (v Value) SetIndex(i int, x interface{}) {
+	if !v.isObject() {
+		panic(&ValueError{"Value.SetIndex", v.Type()})
+	}
+	for len(v.ref.items) <= i {
+		v.ref.items = append(v.ref.items, Value{})
+	}
+	v.ref.items[i] = ValueOf(x)
+}
+
+// Length returns the JavaScript property "length" of v.
+// It panics if v is not a JavaScript object.
+func (v Value) Length() int {
+	return v.Get("length").Int()
+}
+
+// InstanceOf reports whether v is an instance of type t according to JavaScript's instanceof operator.
+func (v Value) InstanceOf(t Value) bool {
+	if !v.isObject() {
+		return false
+	}
+	return v.ref.ctor != nil && v.ref.ctor == t.ref
+}
+
+// Call does a JavaScript call to the method m of value v with the given arguments.
+// It panics if v has no method m.
+func (v Value) Call(m string, args ...interface{}) Value {
+	list := valuesOf(args)
+	record(v, m, list)
+	fn := v.Get(m)
+	if fn.Type() != TypeFunction {
+		typeError("%s is not a function", m)
+	}
+	return fn.call(v, list)
+}
+
+// Invoke does a JavaScript call of the value v with the given arguments.
+// It panics if v is not a JavaScript function.
+func (v Value) Invoke(args ...interface{}) Value {
+	if v.Type() != TypeFunction {
+		panic(&ValueError{"Value.Invoke", v.Type()})
+	}
+	list := valuesOf(args)
+	record(v, "", list)
+	return v.call(Undefined(), list)
+}
+
+// New uses JavaScript's "new" operator with value v as constructor and the given arguments.
+// It panics if v is not a JavaScript function.
+func (v Value) New(args ...interface{}) Value {
+	if v.Type() != TypeFunction {
+		panic(&ValueError{"Value.New", v.Type()})
+	}
+	list := valuesOf(args)
+	record(v, "new", list)
+	this := NewObject()
+	this.ref.ctor = v.ref
+	if ret := v.call(this, list); ret.isObject() {
+		if ret.ref.ctor == nil {
+			ret.ref.ctor = v.ref
+		}
+		return ret
+	}
+	return this
+}
+
+func (v Value) call(this Value, args []Value) Value {
+	if v.ref.released {
+		panic("call to released function")
+	}
+	if v.ref.fn == nil {
+		return Undefined()
+	}
+	return ValueOf(v.ref.fn(this, args))
+}
+
+// FuncOf returns a wrapped function. Func.Release must be called
+// to free up resources when the function will not be used any more.
+func FuncOf(fn func(this Value, args []Value) interface{}) Func {
+	ret := Func{Value: NewFunction(fn)}
+	runtime.lock.Lock()
+	runtime.funcs[ret.ref] = struct{}{}
+	runtime.lock.Unlock()
+	return ret
+}
+
+// Release frees up resources allocated for the function.
+// The function must not be invoked after calling Release.
+func (c Func) Release() {
+	if c.ref == nil || c.ref.released {
+		return
+	}
+	c.ref.released = true
+	runtime.lock.Lock()
+	delete(runtime.funcs, c.ref)
+	runtime.lock.Unlock()
+}
+
+// CopyBytesToGo copies bytes from the Uint8Array src to dst.
+// It returns the number of bytes copied, which will be the minimum of the lengths of src and dst.
+func CopyBytesToGo(dst []byte, src Value) int {
+	n := len(dst)
+	if src.Length() < n {
+		n = src.Length()
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = byte(src.Index(i).Int())
+	}
+	return n
+}
+
+// CopyBytesToJS copies bytes from src to the Uint8Array dst.
+// It returns the number of bytes copied, which will be the minimum of the lengths of src and dst.
+func CopyBytesToJS(dst Value, src []byte) int {
+	n := len(src)
+	if dst.Length() < n {
+		n = dst.Length()
+	}
+	for i := 0; i < n; i++ {
+		dst.SetIndex(i, src[i])
+	}
+	return n
+}
+
+{{range .Arrays}}
+// {{.Name}}ToJS is converting a slice into a javascript array.
+func {{.Name}}ToJS(values []{{.Type}}) Value {
+	items := make([]Value, len(values))
+	for i, v := range values {
+		items[i] = ValueOf(v)
+	}
+	return newArray(items)
+}
+
+// {{.Name}}ToGo is converting a javascript array into a slice.
+func {{.Name}}ToGo(array Value) []{{.Type}} {
+	out := make([]{{.Type}}, array.Length())
+	for i := range out {
+		out[i] = {{.Type}}(array.Index(i).Float())
+	}
+	return out
+}
+{{end}}
+{{end}}
+`
+
+var fakeJSTempl = template.Must(template.New("fakejs").Parse(fakeJSTmplInput))
+
+type fakeJSArray struct {
+	Name, Type string
+}
+
+// typed array conversion functions in jsarray package
+var fakeJSArrays = []fakeJSArray{
+	{"UInt8", "uint8"}, {"Int8", "int8"}, {"UInt16", "uint16"}, {"Int16", "int16"},
+	{"UInt32", "uint32"}, {"Int32", "int32"}, {"Float32", "float32"}, {"Float64", "float64"},
+}
+
+// writeFakeJS is creating the fake javascript runtime package
+func (b *Backend) writeFakeJS(pkg string) (*backend.Source, error) {
+	data := struct {
+		Package string
+		Arrays  []fakeJSArray
+	}{
+		Package: strings.Replace(shortPackageName(pkg), "-", "_", -1),
+		Arrays:  fakeJSArrays,
+	}
+	var buf bytes.Buffer
+	if err := b.tmpl.fakeJS.ExecuteTemplate(&buf, "fakejs", data); err != nil {
+		return nil, err
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &backend.Source{
+		Package: pkg,
+		Name:    strings.ToLower(data.Package) + ".go",
+		Content: content,
+	}, nil
+}
+
+// useFakeJS is changing host source code to use the fake
+// javascript runtime instead of the panicing core/js package
+func useFakeJS(content []byte, pkg string) []byte {
+	replace := []struct{ from, to string }{
+		{`import js "github.com/gowebapi/webapi/core/js"`, `import js "` + pkg + `"`},
+		{`"github.com/gowebapi/webapi/core/jsarray"`, `jsarray "` + pkg + `"`},
+		{`"github.com/gowebapi/webapi/core"`, `core "` + pkg + `"`},
+	}
+	for _, r := range replace {
+		content = bytes.Replace(content, []byte(r.from), []byte(r.to), 1)
+	}
+	if source, err := format.Source(content); err == nil {
+		content = source
+	}
+	return content
+}
@@ -14,11 +14,17 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/gowebapi/webidl-bind/backend"
 	"github.com/gowebapi/webidl-bind/types"
 )
 
 const fileTemplInput = `
 {{define "header"}}
+{{template "file-header" .}}
+{{template "common" .}}
+{{end}}
+
+{{define "file-header"}}
 // Code generated by webidl-bind. DO NOT EDIT.
 
 package {{.Package}}
@@ -30,7 +36,9 @@ import "syscall/js"
 // @IDL-FILES@
 
 // @TRANSFORM-FILES@
+{{end}}
 
+{{define "common"}}
 // workaround for compiler error
 func unused(value interface{}) {
 	// TODO remove this method
@@ -48,6 +56,104 @@ func UnionFromJS(value js.Value) * Union {
 	return & Union{Value: value}
 }
 
+{{if .CallbackScope}}
+// FuncTracker is collecting allocated javascript functions
+// that later should be released.
+type FuncTracker interface {
+	Track(fn js.Func)
+}
+
+// ReleaseScope is holding javascript functions allocated during
+// a scope. All of them are released with a single Release call.
+type ReleaseScope struct {
+	funcs []js.Func
+}
+
+// NewReleaseScope is allocating a new, empty, scope.
+func NewReleaseScope() * ReleaseScope {
+	return & ReleaseScope{}
+}
+
+// Track is adding a function that is released together with the scope.
+func (s * ReleaseScope) Track(fn js.Func) {
+	s.funcs = append(s.funcs, fn)
+}
+
+// Release is releasing all functions that the scope is holding.
+func (s * ReleaseScope) Release() {
+	for _, fn := range s.funcs {
+		fn.Release()
+	}
+	s.funcs = nil
+}
+{{end}}
+
+{{end}}
+
+{{define "event-listener"}}
+// ListenerOptions is optional values used when adding an event listener.
+type ListenerOptions struct {
+	// Capture is dispatching events to the listener before any
+	// target beneath it in the DOM tree.
+	Capture bool
+
+	// Once is removing the listener after the first invocation.
+	Once bool
+
+	// Passive indicate that the listener will never call preventDefault().
+	Passive bool
+
+	// Signal is an AbortSignal that removes the listener when aborted.
+	// Undefined value if not used.
+	Signal js.Value
+}
+
+// JSValue is converting options into a javascript object.
+func (o * ListenerOptions) JSValue() js.Value {
+	ret := js.Global().Get("Object").New()
+	ret.Set("capture", o.Capture)
+	ret.Set("once", o.Once)
+	ret.Set("passive", o.Passive)
+	if o.Signal.Type() != js.TypeUndefined {
+		ret.Set("signal", o.Signal)
+	}
+	return ret
+}
+
+// ListenerHandle is a registered event listener.
+type ListenerHandle struct {
+	target  js.Value
+	event   string
+	fn      js.Func
+	capture bool
+	removed bool
+}
+
+// Func is returning the javascript function that is registered.
+func (h * ListenerHandle) Func() js.Func {
+	return h.fn
+}
+
+func (h * ListenerHandle) add(options * ListenerOptions) {
+	if options == nil {
+		h.target.Call("addEventListener", h.event, h.fn)
+		return
+	}
+	h.capture = options.Capture
+	h.target.Call("addEventListener", h.event, h.fn, options.JSValue())
+}
+
+// Remove is doing removeEventListener with matching options and
+// release the allocated javascript function. A listener that is
+// removed by an AbortSignal still need to be released with Remove.
+func (h * ListenerHandle) Remove() {
+	if h == nil || h.removed {
+		return
+	}
+	h.removed = true
+	h.target.Call("removeEventListener", h.event, h.fn, h.capture)
+	h.fn.Release()
+}
 {{end}}
 `
 
@@ -55,7 +161,8 @@ var fileTempl = template.Must(template.New("file").Parse(fileTemplInput))
 
 // Data in header evaluation
 type fileData struct {
-	Package string
+	Package       string
+	CallbackScope bool
 }
 
 type writeFn func(dst io.Writer, in types.Type) error
@@ -63,12 +170,20 @@ type writeFn func(dst io.Writer, in types.Type) error
 type packageData struct {
 	buf   bytes.Buffer
 	types map[types.Type]struct{}
+
+	// common is true for the file that have package common
+	// types and helpers
+	common bool
 }
 
-type Source struct {
-	Package string
-	name    string
-	Content []byte
+// fileKey is identifying an output file
+type fileKey struct {
+	pkg  string
+	name string
+}
+
+func (k fileKey) String() string {
+	return k.pkg + "/" + k.name
 }
 
 var reservedGoKeywords = map[string]bool{
@@ -81,117 +196,258 @@ var reservedGoKeywords = map[string]bool{
 	"iota":   true,
 }
 
+// Options is controlling how source code is generated
+type Options struct {
+	// SharedConvert is replacing inline conversion code with calls
+	// to conversion functions that are shared inside a package
+	SharedConvert bool
+
+	// CallbackScope is adding callback allocation functions
+	// that release themselves, either after first invocation
+	// or together with a ReleaseScope
+	CallbackScope bool
+
+	// FilePerType is writing every type into its own file instead
+	// of a single file per package
+	FilePerType bool
+
+	// LineDirectives is adding //line directives that point
+	// generated declarations, including function bodies, to the
+	// WebIDL source
+	LineDirectives bool
+
+	// FakeJS is an import path for a programmable fake javascript
+	// runtime that is generated and used in host builds
+	FakeJS string
+
+	// Packages is overriding options for single packages, key is
+	// package name. FakeJS can only be set globally.
+	Packages map[string]Options
+}
+
+// usePackageOptions is changing current options to package overrides
+func (b *Backend) usePackageOptions(pkg string) {
+	b.options = b.baseOptions
+	if override, found := b.baseOptions.Packages[pkg]; found {
+		b.options = override
+		b.options.FakeJS = b.baseOptions.FakeJS
+	}
+}
+
 var specialImportLines = map[string]string{
 	"jsarray": "github.com/gowebapi/webapi/core/jsarray",
 	"core":    "github.com/gowebapi/webapi/core",
+	"context": "context",
 }
 
 // WriteSource is create source code files.
 // returns map["path/filename"]"file content"
-func WriteSource(conv *types.Convert) ([]*Source, error) {
-	oldTB := types.TransformBasic
-	restoreTB := func() { types.TransformBasic = oldTB }
-	defer restoreTB()
-	types.TransformBasic = pkgMgr.transformPackageName
-	target := make(map[string]*packageData)
+func (b *Backend) WriteSource(conv *types.Convert) ([]*backend.Source, error) {
+	b.baseOptions = Options{}
+	if b.Options != nil {
+		b.baseOptions = *b.Options
+	}
+	b.options = b.baseOptions
+	b.tmpl = b.Templates
+	if b.tmpl == nil {
+		b.tmpl = DefaultTemplates()
+	}
+	b.pkgMgr = newPackageManager()
+	b.refs = nil
+	b.tables = make(map[string]*LineTable)
+	oldTB := conv.TransformBasic
+	conv.TransformBasic = b.pkgMgr.transformPackageName
+	defer func() { conv.TransformBasic = oldTB }()
+	target := make(map[fileKey]*packageData)
 	var err error
 	for _, e := range conv.Enums {
 		if e.InUse() {
-			err = writeType(e, target, writeEnum, err)
+			err = b.writeType(e, target, b.writeEnum, err)
 		}
 	}
 	for _, v := range conv.Callbacks {
 		if v.InUse() {
-			err = writeType(v, target, writeCallback, err)
+			err = b.writeType(v, target, b.writeCallback, err)
 		}
 	}
 	for _, v := range conv.Dictionary {
 		if v.InUse() {
-			err = writeType(v, target, writeDictionary, err)
+			err = b.writeType(v, target, b.writeDictionary, err)
 		}
 	}
 	for _, v := range conv.Interface {
 		if v.InUse() {
-			err = writeType(v, target, writeInterface, err)
+			err = b.writeType(v, target, b.writeInterface, err)
 		}
 	}
+	if err == nil {
+		err = b.addCommonFiles(target)
+	}
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("formatting output source code")
-	ret := make([]*Source, 0)
-	for pkg, data := range target {
+	fmt.Fprintln(b.Log, "formatting output source code")
+	ret := make([]*backend.Source, 0)
+	for key, data := range target {
+		pkg := key.pkg
+		b.usePackageOptions(pkg)
 		content := data.buf.Bytes()
+		file := b.pkgMgr.packages[pkg]
+		if data.common {
+			if content, err = b.appendPackageHelpers(content, file); err != nil {
+				return nil, err
+			}
+		}
 		content = sourceCodeRemoveEmptyLines(content)
 		content = sourceInsertInputFileNames(content, data)
-		if content, err = insertImportLines(pkg, content); err != nil {
-			fmt.Fprintf(os.Stderr, "error:%s:unable to remove unused imports from source code: %s\n", pkg, err)
+		if content, err = b.insertImportLines(pkg, content, data.common); err != nil {
+			fmt.Fprintf(os.Stderr, "error:%s:unable to remove unused imports from source code: %s\n", key, err)
 		}
 		if source, err := format.Source(content); err == nil {
 			content = source
 		} else {
 			// we just print this error to get an output file that we
 			// later can correct and fix the bug
-			fmt.Fprintf(os.Stderr, "error:%s:unable to format output source code: %s\n", pkg, err)
+			fmt.Fprintf(os.Stderr, "error:%s:unable to format output source code: %s\n", key, err)
 		}
 		wasm, desktop := createMultieOSLib(content)
-		base := strings.ToLower(shortPackageName(pkg))
-		ret = append(ret, &Source{
-			Package: pkg,
-			name:    base + ".go",
-			Content: desktop,
-		})
-		ret = append(ret, &Source{
-			Package: pkg,
-			name:    base + "_js.go",
-			Content: wasm,
-		})
+		if b.options.FakeJS != "" {
+			desktop = useFakeJS(desktop, b.options.FakeJS)
+		}
+		ret = append(ret, b.newSource(pkg, key.name+".go", desktop))
+		ret = append(ret, b.newSource(pkg, key.name+"_js.go", wasm))
+	}
+	if b.baseOptions.FakeJS != "" {
+		fake, err := b.writeFakeJS(b.baseOptions.FakeJS)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, fake)
 	}
 	sort.Slice(ret, func(i, j int) bool {
 		if ret[i].Package == ret[j].Package {
-			return ret[i].name < ret[j].name
+			return ret[i].Name < ret[j].Name
 		}
 		return ret[i].Package < ret[j].Package
 	})
 	return ret, nil
 }
 
-func writeType(value types.Type, target map[string]*packageData, conv writeFn, err error) error {
+// newSource is removing markers from generated content and save
+// the declarations in a line table
+func (b *Backend) newSource(pkg, name string, content []byte) *backend.Source {
+	content, marks := b.takeMarkers(content)
+	table := newLineTable(content, marks)
+	if b.options.LineDirectives {
+		content, table = insertLineDirectives(content, name, table)
+	}
+	b.tables[pkg+"/"+name] = table
+	return &backend.Source{
+		Package: pkg,
+		Name:    name,
+		Content: content,
+	}
+}
+
+func (b *Backend) writeType(value types.Type, target map[fileKey]*packageData, conv writeFn, err error) error {
 	if err != nil {
 		return err
 	}
-	pkgMgr.setPackageName(value)
-	dst, err := getTarget(value, target)
+	b.pkgMgr.setPackageName(value)
+	b.usePackageOptions(value.Basic().Package)
+	dst, err := b.getTarget(value, target)
 	if err != nil {
 		return err
 	}
+	b.mark(&dst.buf, value.SourceReference())
 	if err := conv(&dst.buf, value); err != nil {
 		return err
 	}
+	if err := b.writeInjectedCode(&dst.buf, value); err != nil {
+		return err
+	}
+	b.mark(&dst.buf, nil)
 	return nil
 }
 
-func getTarget(value types.Type, target map[string]*packageData) (*packageData, error) {
+func (b *Backend) getTarget(value types.Type, target map[fileKey]*packageData) (*packageData, error) {
 	pkg := value.Basic().Package
-	dst, ok := target[pkg]
+	key := fileKey{pkg: pkg, name: b.pkgMgr.currentPackage.baseName()}
+	block := "header"
+	if b.options.FilePerType {
+		key.name = b.pkgMgr.currentPackage.typeFileName(value)
+		block = "file-header"
+	}
+	dst, ok := target[key]
 	if ok {
 		dst.types[value] = struct{}{}
 		return dst, nil
 	}
 	dst = &packageData{
-		types: make(map[types.Type]struct{}),
+		types:  make(map[types.Type]struct{}),
+		common: !b.options.FilePerType,
 	}
 	dst.types[value] = struct{}{}
-	target[pkg] = dst
-	data := fileData{
-		Package: shortPackageName(pkg),
-	}
-	if err := fileTempl.ExecuteTemplate(&dst.buf, "header", data); err != nil {
+	target[key] = dst
+	if err := b.writeFileHeader(&dst.buf, pkg, block); err != nil {
 		return nil, err
 	}
 	return dst, nil
 }
 
+func (b *Backend) writeFileHeader(dst io.Writer, pkg, block string) error {
+	data := fileData{
+		Package:       shortPackageName(pkg),
+		CallbackScope: b.options.CallbackScope,
+	}
+	return b.tmpl.file.ExecuteTemplate(dst, block, data)
+}
+
+// addCommonFiles is adding a file for every package that contains
+// common types and helpers when every type has its own file
+func (b *Backend) addCommonFiles(target map[fileKey]*packageData) error {
+	common := make(map[string]*packageData)
+	for key, data := range target {
+		b.usePackageOptions(key.pkg)
+		if !b.options.FilePerType {
+			continue
+		}
+		dst, found := common[key.pkg]
+		if !found {
+			dst = &packageData{
+				types:  make(map[types.Type]struct{}),
+				common: true,
+			}
+			if err := b.writeFileHeader(&dst.buf, key.pkg, "header"); err != nil {
+				return err
+			}
+			common[key.pkg] = dst
+		}
+		for t := range data.types {
+			dst.types[t] = struct{}{}
+		}
+	}
+	for pkg, data := range common {
+		name := b.pkgMgr.packages[pkg].baseName() + commonFileSuffix
+		target[fileKey{pkg: pkg, name: name}] = data
+	}
+	return nil
+}
+
+// appendPackageHelpers is adding helper types and functions
+// that is only included in packages that is using them
+func (b *Backend) appendPackageHelpers(content []byte, file *packageFile) ([]byte, error) {
+	content = append(content, file.converterSource()...)
+	if file.eventListener {
+		var buf bytes.Buffer
+		if err := b.tmpl.file.ExecuteTemplate(&buf, "event-listener", nil); err != nil {
+			return nil, err
+		}
+		content = append(content, buf.Bytes()...)
+	}
+	return content, nil
+}
+
 // sourceCodeRemoveEmptyLines will remove empty lines
 func sourceCodeRemoveEmptyLines(code []byte) []byte {
 	add := []string{"\\//", "func", "type", "const", "var"}
@@ -244,7 +500,7 @@ func createMultieOSLib(content []byte) (wasm, others []byte) {
 	return
 }
 
-func insertImportLines(pkg string, content []byte) ([]byte, error) {
+func (b *Backend) insertImportLines(pkg string, content []byte, info bool) ([]byte, error) {
 	// first we extract all unresolved symbols to find out
 	// what import lines that is actually is used. there is
 	// logic bug in the current import type detector. for
@@ -274,9 +530,11 @@ func insertImportLines(pkg string, content []byte) ([]byte, error) {
 	}
 
 	// then we are writing the import lines
-	file := pkgMgr.packages[pkg]
+	file := b.pkgMgr.packages[pkg]
 	lines := file.importLines(names, extra, removeImports)
-	lines = lines + "\n" + file.importInfo()
+	if info {
+		lines = lines + "\n" + file.importInfo()
+	}
 	content = bytes.Replace(content, []byte("// @IMPORT@"), []byte(lines), 1)
 	return content, err
 }
@@ -302,23 +560,3 @@ func sourceInsertInputFileNames(content []byte, data *packageData) []byte {
 	content = bytes.Replace(content, []byte("// @TRANSFORM-FILES@"), []byte(modFiles), 1)
 	return content
 }
-
-func (src *Source) Filename(insidePkg string) (string, bool) {
-	full := filepath.Join(src.Package, src.name)
-	if insidePkg == "" {
-		return full, true
-	}
-	limit := insidePkg
-	if !strings.HasSuffix(limit, "/") {
-		limit = limit + "/"
-	} else {
-		insidePkg = insidePkg[0 : len(insidePkg)-1]
-	}
-	if src.Package == insidePkg {
-		return src.name, true
-	}
-	if strings.HasPrefix(src.Package, limit) {
-		return full[len(limit):], true
-	}
-	return full, false
-}
@@ -2,18 +2,28 @@ package gowasm
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/gowebapi/webidl-bind/backend"
 	"github.com/gowebapi/webidl-bind/types"
 )
 
+var update = flag.Bool("update", false, "update golden files in testdata")
+
 func TestCallback(t *testing.T) {
 	standardSetupTest("callback", t)
 }
@@ -34,22 +44,270 @@ func TestInterface(t *testing.T) {
 	standardSetupTest("iface", t)
 }
 
-func standardSetupTest(name string, t *testing.T) *types.Convert {
+func TestSharedConvert(t *testing.T) {
+	src := optionSetupTest("shared", Options{SharedConvert: true}, t)
+	if src == nil {
+		return
+	}
+	content := sourceFile("shared_js.go", src, t)
+	assert.Contains(t, content, "func convertToJS_SeqInt(_in []int) interface{} {")
+	assert.Contains(t, content, "func convertFromJS_SeqInt(_in js.Value) (_out []int) {")
+	assert.Contains(t, content, "func convertFromJS_SeqPtrFoo(_in js.Value) (_out []*Foo) {")
+}
+
+func TestPackageOptions(t *testing.T) {
+	conv := loadFile("testdata/shared/shared.idl", "shared", t)
+	if conv == nil {
+		t.FailNow()
+	}
+	opts := Options{Packages: map[string]Options{
+		"shared": {SharedConvert: true},
+	}}
+	src, err := WriteSource(conv, opts)
+	if !assert.Nil(t, err) || !assert.Equal(t, 2, len(src)) {
+		return
+	}
+	assert.Contains(t, sourceFile("shared_js.go", src, t), "func convertToJS_SeqInt(_in []int) interface{} {")
+
+	src, err = WriteSource(conv, Options{Packages: map[string]Options{"other": {SharedConvert: true}}})
+	if assert.Nil(t, err) {
+		assert.NotContains(t, sourceFile("shared_js.go", src, t), "convertToJS_SeqInt")
+	}
+}
+
+func TestConcurrentWriteSource(t *testing.T) {
+	names := []string{"iface", "dict", "callback", "shared"}
+	convs := []*types.Convert{}
+	expected := [][]*backend.Source{}
+	for _, name := range names {
+		conv := loadFile(fmt.Sprintf("testdata/%s/%s.idl", name, name), name, t)
+		if conv == nil {
+			t.FailNow()
+		}
+		src, err := WriteSource(conv, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		convs = append(convs, conv)
+		expected = append(expected, src)
+	}
+	actual := make([][]*backend.Source, len(convs))
+	var wg sync.WaitGroup
+	for i := range convs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			actual[i], _ = WriteSource(convs[i], Options{})
+		}(i)
+	}
+	wg.Wait()
+	for i := range convs {
+		assert.Equal(t, expected[i], actual[i], names[i])
+	}
+}
+
+func TestCallbackScope(t *testing.T) {
+	optionSetupTest("scope", Options{CallbackScope: true}, t)
+}
+
+func TestEventListener(t *testing.T) {
+	idl := "testdata/event/event.idl"
+	conv := loadFile(idl, "event", t)
+	if conv == nil {
+		t.FailNow()
+	}
+	// same as transform '@event' command is doing
+	foo := conv.Types["Foo"].(*types.Interface)
+	for idx, attr := range foo.Vars {
+		ev := attr.Copy()
+		ev.ShortName = strings.Title(attr.Name().Idl[2:])
+		ev.EventName = attr.Name().Idl[2:]
+		ev.Name().Def = "On" + ev.ShortName
+		ev.Type = conv.Types["Event"]
+		ev.PrimaryEv = idx == 0
+		foo.Events = append(foo.Events, ev)
+	}
+	src, err := WriteSource(conv, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareResult("testdata/event/event.go", src, t)
+	tryCompileResult("testdata/event", t)
+}
+
+func TestInjectedCode(t *testing.T) {
+	conv := loadFile("testdata/inject/inject.idl", "inject", t)
+	if conv == nil {
+		t.FailNow()
+	}
+	// same as a transform file code block is doing
+	conv.Types["Element"].AddInjectedCode(&types.InjectedCode{
+		Ref: &types.Ref{Filename: "inject.md", Line: 4},
+		Code: `import "strings"
+
+// Classes is returning all class names.
+func (_this *Element) Classes() []string {
+	return strings.Fields(_this.GetAttribute("class"))
+}
+`,
+	})
+	conv.Types["Mode"].AddInjectedCode(&types.InjectedCode{
+		Ref:  &types.Ref{Filename: "inject.md", Line: 12},
+		Code: "// IsOpen is true for open mode.\nfunc (this Mode) IsOpen() bool {\n\treturn this == OpenMode\n}\n",
+	})
+	src, err := WriteSource(conv, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareResult("testdata/inject/inject.go", src, t)
+	tryCompileResult("testdata/inject", t)
+
+	src, err = WriteSource(conv, Options{LineDirectives: true})
+	if assert.Nil(t, err) {
+		assert.Contains(t, sourceFile("inject_js.go", src, t), "//line inject.md:8\nfunc (_this *Element) Classes() []string {")
+	}
+}
+
+func TestInjectedCodeErrors(t *testing.T) {
+	for code, msg := range map[string]string{
+		"func (_this *Element) Foo() {\n\treturn 1 +\n}\n": "inject.md:7:1:",
+		"import strings \"bytes\"\n":                       "must be named 'bytes'",
+		"import . \"strings\"\n":                           "unsupported import name",
+	} {
+		conv := loadFile("testdata/inject/inject.idl", "inject", t)
+		if conv == nil {
+			t.FailNow()
+		}
+		conv.Types["Element"].AddInjectedCode(&types.InjectedCode{
+			Ref:  &types.Ref{Filename: "inject.md", Line: 4},
+			Code: code,
+		})
+		_, err := WriteSource(conv, Options{})
+		if assert.NotNil(t, err, code) {
+			assert.Contains(t, err.Error(), msg)
+		}
+	}
+}
+
+func TestFakeJS(t *testing.T) {
+	const fake = "github.com/gowebapi/webidl-bind/gowasm/testdata/fakejs/jsfake"
+	conv := loadFile("testdata/fakejs/fakejs.idl", "fakejs", t)
+	if conv == nil {
+		t.FailNow()
+	}
+	src, err := WriteSource(conv, Options{FakeJS: fake})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(src))
+	for _, s := range src {
+		filename := filepath.Join("testdata/fakejs", s.Name)
+		if s.Package == fake {
+			filename = filepath.Join("testdata/fakejs/jsfake", s.Name)
+		} else if strings.HasSuffix(s.Name, "_js.go") {
+			continue
+		} else {
+			assert.Contains(t, string(s.Content), `import js "`+fake+`"`)
+		}
+		compareGolden(filename, s.Content, t)
+	}
+	tryTestResult("testdata/fakejs", t)
+}
+
+func TestFilePerType(t *testing.T) {
+	conv := loadFile("testdata/pertype/pertype.idl", "pertype", t)
+	if conv == nil {
+		t.FailNow()
+	}
+	src, err := WriteSource(conv, Options{FilePerType: true, SharedConvert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, s := range src {
+		names = append(names, s.Name)
+		if strings.HasSuffix(s.Name, "_js.go") {
+			continue
+		}
+		compareGolden(filepath.Join("testdata/pertype", s.Name), s.Content, t)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{
+		"bar.go", "bar_js.go", "color.go", "color_js.go", "foo.go", "foo_js.go",
+		"handler.go", "handler_js.go", "pertype_common.go", "pertype_common_js.go",
+		"pertype_type.go", "pertype_type_js.go",
+	}, names)
+	tryCompileResult("testdata/pertype", t)
+}
+
+func TestLineDirectives(t *testing.T) {
+	conv := loadFile("testdata/iface/iface.idl", "iface", t)
+	if conv == nil {
+		t.FailNow()
+	}
+	src, err := WriteSource(conv, Options{LineDirectives: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range src {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, s.Name, s.Content, 0)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		found := 0
+		for _, d := range file.Decls {
+			pos := fset.Position(d.Pos())
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Recv.List[0].Names[0].Name == "_this" {
+				assert.Equal(t, "testdata/iface/iface.idl", pos.Filename, fn.Name.Name)
+				found++
+				// lines inside the function is pointing to same declaration
+				for _, st := range fn.Body.List {
+					stmt := fset.Position(st.Pos())
+					assert.Equal(t, pos.Filename, stmt.Filename, fn.Name.Name)
+					assert.Equal(t, pos.Line, stmt.Line, fn.Name.Name)
+				}
+			} else if _, ok := d.(*ast.FuncDecl); ok && pos.Filename == s.Name {
+				// restored position is the real line in the file
+				lines := strings.Split(string(s.Content), "\n")
+				assert.True(t, strings.HasPrefix(lines[pos.Line-1], "func "))
+			}
+		}
+		assert.True(t, found > 0)
+	}
+}
+
+func TestLineDirectivesRawString(t *testing.T) {
+	content := []byte("package foo\n\nfunc Foo() string {\n\treturn `a\nb`\n}\n\nvar Bar int\n")
+	table := newLineTable(content, []lineMark{{line: 3, ref: &types.Ref{Filename: "foo.idl", Line: 7}}, {line: 8}})
+	out, lines := insertLineDirectives(content, "foo_js.go", table)
+	assert.Equal(t, "package foo\n\n//line foo.idl:7\nfunc Foo() string {\n//line foo.idl:7\n\treturn `a\nb`\n"+
+		"//line foo.idl:7\n}\n//line foo_js.go:11\n\nvar Bar int\n", string(out))
+	assert.Equal(t, 4, lines.Lookup(4).From)
+	assert.Equal(t, 9, lines.Lookup(4).To)
+}
+
+func standardSetupTest(name string, t *testing.T) []*backend.Source {
+	return optionSetupTest(name, Options{}, t)
+}
+
+func optionSetupTest(name string, opts Options, t *testing.T) []*backend.Source {
 	idl := fmt.Sprintf("testdata/%s/%s.idl", name, name)
 	actual := fmt.Sprintf("testdata/%s/%s.go", name, name)
-	return simpleTest(idl, name, actual, t)
+	return simpleTest(idl, name, actual, opts, t)
 }
 
-func simpleTest(idl, pkg, actual string, t *testing.T) *types.Convert {
+func simpleTest(idl, pkg, actual string, opts Options, t *testing.T) []*backend.Source {
 	if conv := loadFile(idl, pkg, t); conv != nil {
-		if src, err := WriteSource(conv); err != nil {
+		src, err := WriteSource(conv, opts)
+		if err != nil {
 			t.Error(err)
-		} else {
-			compareResult(actual, src, t)
-			folder := filepath.Dir(idl)
-			tryCompileResult(folder, t)
+			return nil
 		}
-		return conv
+		compareResult(actual, src, t)
+		folder := filepath.Dir(idl)
+		tryCompileResult(folder, t)
+		return src
 	}
 	t.Fail()
 	return nil
@@ -80,7 +338,7 @@ func loadFile(filename string, pkg string, t *testing.T) *types.Convert {
 	return conv
 }
 
-func compareResult(expectedFile string, actual []*Source, t *testing.T) {
+func compareResult(expectedFile string, actual []*backend.Source, t *testing.T) {
 	expected, err := ioutil.ReadFile(expectedFile + "_actual")
 	if err != nil {
 		t.Log(err)
@@ -106,9 +364,43 @@ func compareResult(expectedFile string, actual []*Source, t *testing.T) {
 	assert.Equal(t, 1, tested)
 }
 
+// compareGolden is comparing generated content with a file in
+// testdata. With -update the file is written instead.
+func compareGolden(filename string, content []byte, t *testing.T) {
+	if *update {
+		t.Log("saving file", filename)
+		if err := os.MkdirAll(filepath.Dir(filename), 0775); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, content, 0664); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(expected, content) {
+		t.Errorf("%s: generated content differ, run with -update to save it", filename)
+	}
+}
+
+// sourceFile is returning the content of a generated file
+func sourceFile(name string, src []*backend.Source, t *testing.T) string {
+	for _, s := range src {
+		if s.Name == name {
+			return string(s.Content)
+		}
+	}
+	t.Errorf("missing generated file %s", name)
+	return ""
+}
+
 func tryCompileResult(folder string, t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	p := exec.Command("go", "build", "-i")
+	p := exec.Command("go", "build")
 	p.Dir = folder
 	// p.Stdout = os.Stdout
 	// p.Stderr = os.Stderr
@@ -121,3 +413,17 @@ func tryCompileResult(folder string, t *testing.T) {
 		t.Error(stderr.String())
 	}
 }
+
+func tryTestResult(folder string, t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	p := exec.Command("go", "test")
+	p.Dir = folder
+	p.Stdout = &stdout
+	p.Stderr = &stderr
+	t.Logf("running '%s' in folder %s\n", strings.Join(p.Args, " "), folder)
+	if err := p.Run(); err != nil {
+		t.Error("command failed", err)
+		t.Error(stdout.String())
+		t.Error(stderr.String())
+	}
+}
@@ -0,0 +1,129 @@
+package gowasm
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// package line added in front of a code block before parsing it
+const injectedCodePrefix = "package injected\n"
+
+// writeInjectedCode is appending hand-written code blocks from
+// transform files after the generated type
+func (b *Backend) writeInjectedCode(dst io.Writer, value types.Type) error {
+	for _, code := range value.InjectedCode() {
+		decls, err := b.parseInjectedCode(code)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(dst, "\n"+decls+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseInjectedCode is validating a code block and resolve its
+// import statements. the declarations without imports are returned
+func (b *Backend) parseInjectedCode(code *types.InjectedCode) (string, error) {
+	src := injectedCodePrefix + code.Code
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, code.Ref.Filename, src, parser.DeclarationErrors|parser.ParseComments)
+	if err != nil {
+		return "", injectedCodeError(code.Ref, err)
+	}
+	for _, spec := range file.Imports {
+		if err := b.resolveInjectedImport(code.Ref, fset, spec); err != nil {
+			return "", err
+		}
+	}
+	start := len(injectedCodePrefix)
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			start = fset.Position(gen.End()).Offset
+		}
+	}
+	// every declaration is marked with its line in the transform file
+	var out strings.Builder
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+		pos, begin := fset.Position(d.Pos()), d.Pos()
+		if doc := declDoc(d); doc != nil {
+			begin = doc.Pos()
+		}
+		offset := fset.Position(begin).Offset
+		out.WriteString(src[start:offset])
+		b.mark(&out, &types.Ref{
+			Filename: code.Ref.Filename,
+			Line:     code.Ref.Line + pos.Line - 1,
+		})
+		start = offset
+	}
+	out.WriteString(src[start:])
+	return strings.TrimSpace(out.String()), nil
+}
+
+// declDoc is returning the doc comment of a declaration
+func declDoc(d ast.Decl) *ast.CommentGroup {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// resolveInjectedImport is adding an import line to the current
+// package file. the name used in the code block must be the same
+// as the one in generated code
+func (b *Backend) resolveInjectedImport(ref *types.Ref, fset *token.FileSet, spec *ast.ImportSpec) error {
+	path, _ := strconv.Unquote(spec.Path.Value)
+	name := shortPackageName(path)
+	if spec.Name != nil {
+		name = spec.Name.Name
+	}
+	line := ref.Line + fset.Position(spec.Pos()).Line - 1
+	if name == "_" || name == "." {
+		return fmt.Errorf("%s:%d: unsupported import name '%s'", ref.Filename, line, name)
+	}
+	if path == "syscall/js" && name == "js" {
+		// always imported in the file header
+		return nil
+	}
+	if special, found := specialImportLines[name]; found && special == path {
+		return nil
+	}
+	imp := b.pkgMgr.currentPackage.get(path)
+	if imp.shortName != name {
+		return fmt.Errorf("%s:%d: import \"%s\" must be named '%s' in generated code",
+			ref.Filename, line, path, imp.shortName)
+	}
+	return nil
+}
+
+// injectedCodeError is converting parser error positions into
+// transform file lines
+func injectedCodeError(ref *types.Ref, err error) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return fmt.Errorf("%s: invalid go code: %s", ref, err)
+	}
+	lines := make([]string, 0, len(list))
+	for _, e := range list {
+		lines = append(lines, fmt.Sprintf("%s:%d:%d: invalid go code: %s",
+			ref.Filename, ref.Line+e.Pos.Line-1, e.Pos.Column, e.Msg))
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
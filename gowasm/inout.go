@@ -290,15 +290,15 @@ func setupVarName(value string, idx int, name string, variadic bool) string {
 	return value
 }
 
-func writeInOutToWasm(data *inoutData, assign string, use useInOut, dst io.Writer) error {
-	return writeInOutLoop(data, assign, use, inoutToTmpl, dst)
+func (b *Backend) writeInOutToWasm(data *inoutData, assign string, use useInOut, dst io.Writer) error {
+	return b.writeInOutLoop(data, assign, use, b.tmpl.inoutTo, dst)
 }
 
-func writeInOutFromWasm(data *inoutData, assign string, use useInOut, dst io.Writer) error {
-	return writeInOutLoop(data, assign, use, inoutFromTmpl, dst)
+func (b *Backend) writeInOutFromWasm(data *inoutData, assign string, use useInOut, dst io.Writer) error {
+	return b.writeInOutLoop(data, assign, use, b.tmpl.inoutFrom, dst)
 }
 
-func writeInOutLoop(data *inoutData, assign string, use useInOut, tmpl *template.Template, dst io.Writer) error {
+func (b *Backend) writeInOutLoop(data *inoutData, assign string, use useInOut, tmpl *template.Template, dst io.Writer) error {
 	for _, p := range data.ParamList {
 		p.Var = p.Info.VarOut
 		if use == useIn {
@@ -313,7 +313,7 @@ func writeInOutLoop(data *inoutData, assign string, use useInOut, tmpl *template
 		if _, err := io.WriteString(dst, start); err != nil {
 			return err
 		}
-		code := inoutGetToFromWasm(p.Type, p.Info, p.Out, p.In, idx, use, tmpl)
+		code := b.inoutGetToFromWasm(p.Type, p.Info, p.Out, p.In, idx, use, tmpl)
 		if _, err := io.WriteString(dst, code); err != nil {
 			return err
 		}
@@ -329,7 +329,17 @@ func writeInOutLoop(data *inoutData, assign string, use useInOut, tmpl *template
 	return nil
 }
 
-func inoutGetToFromWasm(t types.TypeRef, info *types.TypeInfo, out, in string, idx int, use useInOut, tmpl *template.Template) string {
+func (b *Backend) inoutGetToFromWasm(t types.TypeRef, info *types.TypeInfo, out, in string, idx int, use useInOut, tmpl *template.Template) string {
+	if b.options.SharedConvert {
+		if code, ok := b.sharedConvertCall(t, info, out, in, use, tmpl); ok {
+			return code
+		}
+	}
+	return b.inoutConvertCode(t, info, out, in, idx, use, tmpl)
+}
+
+// inoutConvertCode is creating inline conversion code for a single value
+func (b *Backend) inoutConvertCode(t types.TypeRef, info *types.TypeInfo, out, in string, idx int, use useInOut, tmpl *template.Template) string {
 	if info == nil {
 		panic("null")
 		// info = t.DefaultParam()
@@ -367,12 +377,12 @@ func inoutGetToFromWasm(t types.TypeRef, info *types.TypeInfo, out, in string, i
 	if seq, ok := t.(*types.SequenceType); ok {
 		sp := strconv.Itoa(idx)
 		data.InnerInfo, data.InnerType = seq.Elem.DefaultParam()
-		data.Inner = inoutGetToFromWasm(data.InnerType, data.InnerInfo, "__seq_out"+sp, "__seq_in"+sp, idx+1, use, tmpl)
+		data.Inner = b.inoutGetToFromWasm(data.InnerType, data.InnerInfo, "__seq_out"+sp, "__seq_in"+sp, idx+1, use, tmpl)
 	}
 	if data.Info.Variadic {
 		copy := *data.Info
 		copy.Variadic = false
-		data.Inner = inoutGetToFromWasm(data.Type, &copy, "__out", "__in", idx+1, use, tmpl)
+		data.Inner = b.inoutGetToFromWasm(data.Type, &copy, "__out", "__in", idx+1, use, tmpl)
 		t = types.ChangeTemplateName(t, "variadic")
 	}
 	return convertType(t, data, tmpl) + "\n"
@@ -143,6 +143,42 @@ func (_this * {{.If.Basic.Def}} ) AddEvent{{.Var.ShortName}} (listener func (eve
 	return cb
 }
 
+// AddEvent{{.Var.ShortName}}WithOptions is doing AddEventListener for '{{.Var.ShortName}}'
+// on target with options. Listener is removed and released by the returned handle.
+func (_this * {{.If.Basic.Def}} ) AddEvent{{.Var.ShortName}}WithOptions (listener func (event {{.Type.Input}}, currentTarget * {{.If.Basic.Def}} ), options * ListenerOptions ) * ListenerHandle {
+	handle := & ListenerHandle{ target: _this.Value_JS, event: "{{.Var.EventName}}" }
+	if options != nil && options.Once {
+		next := listener
+		listener = func(event {{.Type.Input}}, currentTarget * {{.If.Basic.Def}} ) {
+			handle.Remove()
+			next(event, currentTarget)
+		}
+	}
+	handle.fn = eventFunc{{.If.Basic.Def}}_{{.Type.GoTagText}} (listener)
+	handle.add(options)
+	return handle
+}
+
+// Events{{.Var.ShortName}} is subscribing to '{{.Var.ShortName}}' events on target.
+// Events are delivered into the returned channel without blocking
+// and are dropped when the channel buffer is full. The listener is
+// removed and the channel closed when ctx is done.
+func (_this * {{.If.Basic.Def}} ) Events{{.Var.ShortName}} (ctx context.Context, buffer int) <-chan {{.Type.Input}} {
+	ch := make(chan {{.Type.Input}}, buffer)
+	handle := _this.AddEvent{{.Var.ShortName}}WithOptions(func (event {{.Type.Input}}, currentTarget * {{.If.Basic.Def}} ) {
+		select {
+		case ch <- event:
+		default:
+		}
+	}, nil)
+	go func() {
+		<-ctx.Done()
+		handle.Remove()
+		close(ch)
+	}()
+	return ch
+}
+
 // Set{{.Name.Def}} is assigning a function to '{{.Var.Name.Idl}}'. This
 // This method is returning allocated javascript function that need to be released.
 func (_this * {{.If.Basic.Def}} ) Set{{.Name.Def}} (listener func (event {{.Type.Input}}, currentTarget * {{.If.Basic.Def}} ) ) js.Func {
@@ -277,6 +313,32 @@ func New{{.Type.Def}}Func( f func( {{(index .Methods 0).To.Params}} ) ( {{(index
 	{{end}}
 	return ret
 }
+
+{{if and .Scope .If.FunctionCB}}
+// New{{.Type.Def}}FuncOnce is allocating a new javascript
+// function that is released after the first invocation.
+func New{{.Type.Def}}FuncOnce( f func( {{(index .Methods 0).To.Params}} ) ( {{(index .Methods 0).ReturnList}} ) ) * {{.Type.Def}}Value {
+	var ret * {{.Type.Def}}Value
+	ret = New{{.Type.Def}}Func(func( {{(index .Methods 0).To.Params}} ) ( {{(index .Methods 0).ReturnList}} ) {
+		defer ret.Release()
+		{{if not (index .Methods 0).IsVoidReturn}}return {{end}} f( {{range $idx, $value := (index .Methods 0).To.ParamList}} {{if ge $idx 1}},{{end}} {{$value.Name}} {{end}} )
+	})
+	return ret
+}
+{{end}}
+{{end}}
+
+{{if .Scope}}
+// New{{.Type.Def}}Scope is allocating a new javascript object that
+// implements {{.Type.Def}}. Allocated functions are released
+// together with scope.
+func New{{.Type.Def}}Scope(scope FuncTracker, callback {{.Type.Def}} ) * {{.Type.Def}}Value {
+	ret := New{{.Type.Def}}(callback)
+	for i := range ret.Functions {
+		scope.Track(ret.Functions[i])
+	}
+	return ret
+}
 {{end}}
 
 // {{.Type.Def}}FromJS is taking an javascript object that reference to a 
@@ -404,52 +466,55 @@ type interfaceMethod struct {
 	ArgVar       string
 }
 
-func writeInterface(dst io.Writer, input types.Type) error {
+func (b *Backend) writeInterface(dst io.Writer, input types.Type) error {
 	value := input.(*types.Interface)
 	if value.Callback {
-		return writeCallbackInterface(value, dst)
+		return b.writeCallbackInterface(value, dst)
 	}
 	data := &interfaceData{
 		If: value,
 	}
 	data.Type, data.Ref = value.DefaultParam()
 	if !value.Global {
-		if err := interfaceTmpl.ExecuteTemplate(dst, "header", data); err != nil {
+		if err := b.tmpl.iface.ExecuteTemplate(dst, "header", data); err != nil {
 			return err
 		}
 	}
-	if err := writeInterfaceConst(value.Consts, value, dst); err != nil {
+	if err := b.writeInterfaceConst(value.Consts, value, dst); err != nil {
 		return err
 	}
-	if err := writeInterfaceVars(value.StaticVars, value, "get-static-attribute", "set-static-attribute", dst); err != nil {
+	if err := b.writeInterfaceVars(value.StaticVars, value, "get-static-attribute", "set-static-attribute", dst); err != nil {
 		return err
 	}
-	if err := writeInterfaceMethods(value.StaticMethod, value, "static-method", useIn, dst); err != nil {
+	if err := b.writeInterfaceMethods(value.StaticMethod, value, "static-method", useIn, dst); err != nil {
 		return err
 	}
 	if value.Constructor != nil {
-		if err := writeInterfaceMethod(value.Constructor, value, "constructor", useIn, dst); err != nil {
+		if err := b.writeInterfaceMethod(value.Constructor, value, "constructor", useIn, dst); err != nil {
 			return err
 		}
 	}
-	if err := writeInterfaceVars(value.Vars, value, "get-object-attribute", "set-object-attribute", dst); err != nil {
+	if err := b.writeInterfaceVars(value.Vars, value, "get-object-attribute", "set-object-attribute", dst); err != nil {
 		return err
 	}
-	// if err := writeInterfaceVars(value.Events, value, "get-object-attribute", "set-event-attribute", dst); err != nil {
+	// if err := b.writeInterfaceVars(value.Events, value, "get-object-attribute", "set-event-attribute", dst); err != nil {
 	// 	return err
 	// }
-	if err := writeInterfaceVars(value.Events, value, "set-event-attribute", "", dst); err != nil {
+	if len(value.Events) > 0 {
+		b.pkgMgr.currentPackage.eventListener = true
+	}
+	if err := b.writeInterfaceVars(value.Events, value, "set-event-attribute", "", dst); err != nil {
 		return err
 	}
-	if err := writeInterfaceMethods(value.Method, value, "object-method", useIn, dst); err != nil {
+	if err := b.writeInterfaceMethods(value.Method, value, "object-method", useIn, dst); err != nil {
 		return err
 	}
 	return nil
 }
 
 // callback interface code
-func writeCallbackInterface(value *types.Interface, dst io.Writer) error {
-	if err := writeInterfaceConst(value.Consts, value, dst); err != nil {
+func (b *Backend) writeCallbackInterface(value *types.Interface, dst io.Writer) error {
+	if err := b.writeInterfaceConst(value.Consts, value, dst); err != nil {
 		return err
 	}
 	// first we set up the method information
@@ -475,28 +540,30 @@ func writeCallbackInterface(value *types.Interface, dst io.Writer) error {
 		Ref     types.TypeRef
 		Methods []*interfaceMethod
 		If      *types.Interface
+		Scope   bool
 	}{
 		Methods: methods,
 		If:      value,
+		Scope:   b.options.CallbackScope,
 	}
 	data.Type, data.Ref = value.DefaultParam()
-	if err := interfaceTmpl.ExecuteTemplate(dst, "callback-header", data); err != nil {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, "callback-header", data); err != nil {
 		return err
 	}
 	for _, m := range methods {
 		assign := ""
-		if err := writeInterfaceCallbackMethod(m, assign, "callback-allocate", dst); err != nil {
+		if err := b.writeInterfaceCallbackMethod(m, assign, "callback-allocate", dst); err != nil {
 			return err
 		}
 	}
-	if err := writeInterfaceMethods(value.Method, value, "callback-invoke", useOut, dst); err != nil {
+	if err := b.writeInterfaceMethods(value.Method, value, "callback-invoke", useOut, dst); err != nil {
 		return err
 	}
 	return nil
 }
 
-func writeInterfaceConst(vars []*types.IfConst, main *types.Interface, dst io.Writer) error {
-	if err := interfaceTmpl.ExecuteTemplate(dst, "const-var-start", vars); err != nil {
+func (b *Backend) writeInterfaceConst(vars []*types.IfConst, main *types.Interface, dst io.Writer) error {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, "const-var-start", vars); err != nil {
 		return err
 	}
 	for idx, a := range vars {
@@ -517,30 +584,31 @@ func writeInterfaceConst(vars []*types.IfConst, main *types.Interface, dst io.Wr
 		if types.IsString(data.Type) {
 			data.Value = "\"" + data.Value + "\""
 		}
-		if err := interfaceTmpl.ExecuteTemplate(dst, "const-var", data); err != nil {
+		if err := b.tmpl.iface.ExecuteTemplate(dst, "const-var", data); err != nil {
 			return err
 		}
 	}
-	if err := interfaceTmpl.ExecuteTemplate(dst, "const-var-end", vars); err != nil {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, "const-var-end", vars); err != nil {
 		return err
 	}
 	return nil
 }
 
-func writeInterfaceVars(vars []*types.IfVar, main *types.Interface, get, set string, dst io.Writer) error {
+func (b *Backend) writeInterfaceVars(vars []*types.IfVar, main *types.Interface, get, set string, dst io.Writer) error {
 	for _, a := range vars {
+		b.mark(dst, a.SourceReference())
 		typ, ref := a.Type.DefaultParam()
 		ret := ""
 		if a.Type.NeedRelease() {
 			ret = "(_release ReleasableApiResource)"
 		}
 		idx := 0
-		from := inoutParamStart(ref, typ, "ret", "value", idx, useOut, inoutFromTmpl)
-		from += inoutGetToFromWasm(ref, typ, "ret", "value", idx, useOut, inoutFromTmpl)
-		from += inoutParamEnd(typ, "", inoutFromTmpl)
-		to := inoutParamStart(ref, typ, "input", "value", idx, useIn, inoutToTmpl)
-		to += inoutGetToFromWasm(ref, typ, "input", "value", idx, useIn, inoutToTmpl)
-		to += inoutParamEnd(typ, "", inoutToTmpl)
+		from := inoutParamStart(ref, typ, "ret", "value", idx, useOut, b.tmpl.inoutFrom)
+		from += b.inoutGetToFromWasm(ref, typ, "ret", "value", idx, useOut, b.tmpl.inoutFrom)
+		from += inoutParamEnd(typ, "", b.tmpl.inoutFrom)
+		to := inoutParamStart(ref, typ, "input", "value", idx, useIn, b.tmpl.inoutTo)
+		to += b.inoutGetToFromWasm(ref, typ, "input", "value", idx, useIn, b.tmpl.inoutTo)
+		to += inoutParamEnd(typ, "", b.tmpl.inoutTo)
 		in := &interfaceAttribute{
 			Name: *a.Name(),
 			Type: typ,
@@ -551,28 +619,29 @@ func writeInterfaceVars(vars []*types.IfVar, main *types.Interface, get, set str
 			Ret:  ret,
 			Var:  a,
 		}
-		if err := interfaceTmpl.ExecuteTemplate(dst, get, in); err != nil {
+		if err := b.tmpl.iface.ExecuteTemplate(dst, get, in); err != nil {
 			return err
 		}
 		if !a.Readonly && set != "" {
-			if err := interfaceTmpl.ExecuteTemplate(dst, set, in); err != nil {
+			if err := b.tmpl.iface.ExecuteTemplate(dst, set, in); err != nil {
 				return err
 			}
 		}
+		b.mark(dst, main.SourceReference())
 	}
 	return nil
 }
 
-func writeInterfaceMethods(methods []*types.IfMethod, main *types.Interface, tmpl string, use useInOut, dst io.Writer) error {
+func (b *Backend) writeInterfaceMethods(methods []*types.IfMethod, main *types.Interface, tmpl string, use useInOut, dst io.Writer) error {
 	for _, m := range methods {
-		if err := writeInterfaceMethod(m, main, tmpl, use, dst); err != nil {
+		if err := b.writeInterfaceMethod(m, main, tmpl, use, dst); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func writeInterfaceMethod(m *types.IfMethod, main *types.Interface, tmpl string, use useInOut, dst io.Writer) error {
+func (b *Backend) writeInterfaceMethod(m *types.IfMethod, main *types.Interface, tmpl string, use useInOut, dst io.Writer) error {
 	to := setupInOutWasmData(m.Params, "@name@", "_p%d", use)
 	retLang, retList, isVoid := calculateMethodReturn(m.Return, to.ReleaseHdl)
 	in := &interfaceMethod{
@@ -585,47 +654,51 @@ func writeInterfaceMethod(m *types.IfMethod, main *types.Interface, tmpl string,
 		To:           to,
 		ArgVar:       calculateMethodArgsSize(to),
 	}
-	if err := interfaceTmpl.ExecuteTemplate(dst, tmpl+"-start", in); err != nil {
+	b.mark(dst, m.SourceReference())
+	if err := b.tmpl.iface.ExecuteTemplate(dst, tmpl+"-start", in); err != nil {
 		return err
 	}
 	assign := "_args[%d] = _p%d; _end++"
-	if err := writeInOutToWasm(in.To, assign, useIn, dst); err != nil {
+	if err := b.writeInOutToWasm(in.To, assign, useIn, dst); err != nil {
 		return err
 	}
-	if err := interfaceTmpl.ExecuteTemplate(dst, tmpl+"-invoke", in); err != nil {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, tmpl+"-invoke", in); err != nil {
 		return err
 	}
 	if !in.IsVoidReturn {
 		result := setupInOutWasmForType(in.Method.Return, "_what_return_name", "_returned", "_converted", useOut)
-		if err := writeInOutFromWasm(result, "", useOut, dst); err != nil {
+		if err := b.writeInOutFromWasm(result, "", useOut, dst); err != nil {
 			return err
 		}
 	}
-	if err := interfaceTmpl.ExecuteTemplate(dst, tmpl+"-end", in); err != nil {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, tmpl+"-end", in); err != nil {
 		return err
 	}
+	b.mark(dst, main.SourceReference())
 	return nil
 }
 
-func writeInterfaceCallbackMethod(in *interfaceMethod, assign, tmpl string, dst io.Writer) error {
-	if err := interfaceTmpl.ExecuteTemplate(dst, tmpl+"-start", in); err != nil {
+func (b *Backend) writeInterfaceCallbackMethod(in *interfaceMethod, assign, tmpl string, dst io.Writer) error {
+	b.mark(dst, in.Method.SourceReference())
+	if err := b.tmpl.iface.ExecuteTemplate(dst, tmpl+"-start", in); err != nil {
 		return err
 	}
-	if err := writeInOutFromWasm(in.To, assign, useOut, dst); err != nil {
+	if err := b.writeInOutFromWasm(in.To, assign, useOut, dst); err != nil {
 		return err
 	}
-	if err := interfaceTmpl.ExecuteTemplate(dst, tmpl+"-invoke", in); err != nil {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, tmpl+"-invoke", in); err != nil {
 		return err
 	}
 	if !in.IsVoidReturn {
 		result := setupInOutWasmForType(in.Method.Return, "_what_return_name", "_returned", "_converted", useOut)
-		if err := writeInOutToWasm(result, "", useOut, dst); err != nil {
+		if err := b.writeInOutToWasm(result, "", useOut, dst); err != nil {
 			return err
 		}
 	}
-	if err := interfaceTmpl.ExecuteTemplate(dst, tmpl+"-end", in); err != nil {
+	if err := b.tmpl.iface.ExecuteTemplate(dst, tmpl+"-end", in); err != nil {
 		return err
 	}
+	b.mark(dst, in.If.SourceReference())
 	return nil
 }
 func calculateMethodReturn(t types.TypeRef, releaseHdl bool) (lang, list string, isVoid bool) {
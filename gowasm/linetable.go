@@ -0,0 +1,223 @@
+package gowasm
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/backend"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// LineEntry is the line range of a top level declaration
+type LineEntry struct {
+	From, To int
+
+	// Decl is the declaration name, e.g. Foo.SetBar
+	Decl string
+
+	// Ref is the WebIDL source, can be nil
+	Ref *types.Ref
+}
+
+// LineTable is mapping lines in a generated file to declarations
+type LineTable struct {
+	Entries []*LineEntry
+}
+
+// refMarker is a comment line written in front of generated code
+// with the index of the source reference the code is coming from.
+// it is removed from the output when the line table is created.
+// a block comment is used as gofmt is leaving it untouched.
+const refMarker = "/*webidl-bind:ref %d*/"
+
+// lineMark is the first line of code after a marker
+type lineMark struct {
+	line int
+	ref  *types.Ref
+}
+
+// mark is writing a marker, code that follows belongs to ref
+func (b *Backend) mark(dst io.Writer, ref *types.Ref) {
+	fmt.Fprintf(dst, "\n"+refMarker+"\n", len(b.refs))
+	b.refs = append(b.refs, ref)
+}
+
+// LineTable is returning the declarations of a file created by last
+// call to WriteSource, nil if the file is unknown
+func (b *Backend) LineTable(src *backend.Source) *LineTable {
+	return b.tables[src.Package+"/"+src.Name]
+}
+
+// takeMarkers is removing all markers from the content and return
+// the line each of them is pointing to
+func (b *Backend) takeMarkers(content []byte) ([]byte, []lineMark) {
+	var out bytes.Buffer
+	var marks []lineMark
+	lines := strings.SplitAfter(string(content), "\n")
+	written := 0
+	blank, removed := false, false
+	for _, line := range lines {
+		var idx int
+		if _, err := fmt.Sscanf(strings.TrimSpace(line), refMarker, &idx); err == nil {
+			marks = append(marks, lineMark{line: written + 1, ref: b.refs[idx]})
+			removed = true
+			continue
+		}
+		empty := strings.TrimSpace(line) == ""
+		if empty && blank && removed {
+			// gofmt is having an empty line on both sides of the marker
+			continue
+		}
+		blank, removed = empty, false
+		out.WriteString(line)
+		written++
+	}
+	// a marker at the end is leaving an empty line
+	return append(bytes.TrimRight(out.Bytes(), "\n"), '\n'), marks
+}
+
+// newLineTable is creating a line table for a generated file where
+// every declaration gets the reference of the marker before it
+func newLineTable(content []byte, marks []lineMark) *LineTable {
+	table := &LineTable{}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, 0)
+	if err != nil {
+		return table
+	}
+	add := func(node ast.Node, decl string) {
+		from := fset.Position(node.Pos()).Line
+		var ref *types.Ref
+		for _, m := range marks {
+			if m.line > from {
+				break
+			}
+			ref = m.ref
+		}
+		table.Entries = append(table.Entries, &LineEntry{
+			From: from,
+			To:   fset.Position(node.End()).Line,
+			Decl: decl,
+			Ref:  ref,
+		})
+	}
+	for _, d := range file.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && len(d.Recv.List) == 1 {
+				add(d, receiverName(d.Recv.List[0].Type)+"."+d.Name.Name)
+			} else {
+				add(d, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					add(spec, spec.Name.Name)
+				case *ast.ValueSpec:
+					if len(spec.Names) > 0 {
+						add(spec, spec.Names[0].Name)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(table.Entries, func(i, j int) bool { return table.Entries[i].From < table.Entries[j].From })
+	return table
+}
+
+// Lookup is returning the declaration that contains given line
+func (t *LineTable) Lookup(line int) *LineEntry {
+	idx := sort.Search(len(t.Entries), func(i int) bool { return t.Entries[i].From > line })
+	if idx == 0 {
+		return nil
+	}
+	if e := t.Entries[idx-1]; line <= e.To {
+		return e
+	}
+	return nil
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// insertLineDirectives is adding a //line directive in front of every
+// line of a declaration that have a WebIDL source reference, and
+// another one after the declaration that restore the position in the
+// generated file. Both the declaration and code in the body, e.g. a
+// panic, is pointing to WebIDL. Lines inside a multi-line string or
+// comment can't have a directive and is counted from the line before.
+// The returned table is using the new lines.
+func insertLineDirectives(content []byte, filename string, table *LineTable) ([]byte, *LineTable) {
+	lines := strings.SplitAfter(string(content), "\n")
+	mapped := make([]*LineEntry, len(lines)+1)
+	for _, e := range table.Entries {
+		if e.Ref != nil && e.Ref.Filename != "" {
+			for line := e.From; line <= e.To && line < len(mapped); line++ {
+				mapped[line] = e
+			}
+		}
+	}
+	inside := tokenContinuation(content)
+	var out bytes.Buffer
+	moved := make([]int, len(lines)+1)
+	written := 0
+	for idx, line := range lines {
+		e := mapped[idx+1]
+		if e != nil && !inside[idx+1] {
+			fmt.Fprintf(&out, "//line %s:%d\n", e.Ref.Filename, e.Ref.Line)
+			written++
+		}
+		out.WriteString(line)
+		written++
+		moved[idx+1] = written
+		if e != nil && e.To == idx+1 {
+			fmt.Fprintf(&out, "//line %s:%d\n", filename, written+2)
+			written++
+		}
+	}
+	ret := &LineTable{}
+	for _, e := range table.Entries {
+		c := *e
+		c.From, c.To = moved[e.From], moved[e.To]
+		ret.Entries = append(ret.Entries, &c)
+	}
+	return out.Bytes(), ret
+}
+
+// tokenContinuation is returning all lines that start inside a
+// token, e.g. a raw string or block comment over several lines
+func tokenContinuation(content []byte) map[int]bool {
+	ret := make(map[int]bool)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(content))
+	var s scanner.Scanner
+	s.Init(file, content, nil, scanner.ScanComments)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		} else if tok != token.STRING && tok != token.COMMENT {
+			continue
+		}
+		from := fset.Position(pos).Line
+		for line := from + 1; line <= from+strings.Count(lit, "\n"); line++ {
+			ret[line] = true
+		}
+	}
+	return ret
+}
@@ -22,6 +22,30 @@ type packageFile struct {
 	imports map[string]*packageImport
 	used    map[string]string
 	types   map[string]struct{}
+
+	// shared conversion functions
+	converters map[string]*sharedConverter
+
+	// event listener helper types are used
+	eventListener bool
+
+	// output file names, when every type has its own file
+	files map[string]struct{}
+}
+
+// suffix of the file with common package content
+const commonFileSuffix = "_common"
+
+// filename suffixes that have a special meaning for go build
+var reservedFileSuffix = map[string]bool{
+	"test": true, "js": true, "wasm": true, "common": true,
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+	"hurd": true, "illumos": true, "ios": true, "linux": true, "nacl": true,
+	"netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+	"windows": true, "zos": true,
+	"386": true, "amd64": true, "arm": true, "arm64": true, "loong64": true,
+	"mips": true, "mipsle": true, "mips64": true, "mips64le": true, "ppc64": true,
+	"ppc64le": true, "riscv64": true, "s390x": true,
 }
 
 type packageImport struct {
@@ -29,8 +53,10 @@ type packageImport struct {
 	fullName  string
 }
 
-var pkgMgr = packageManager{
-	packages: make(map[string]*packageFile),
+func newPackageManager() packageManager {
+	return packageManager{
+		packages: make(map[string]*packageFile),
+	}
 }
 
 // FormatPkg is used to get a default package name from a filename
@@ -74,16 +100,44 @@ func (t *packageManager) setPackageName(typ types.Type) {
 	current, ok := t.packages[pkg]
 	if !ok {
 		current = &packageFile{
-			name:    pkg,
-			imports: make(map[string]*packageImport),
-			used:    make(map[string]string),
-			types:   make(map[string]struct{}),
+			name:       pkg,
+			imports:    make(map[string]*packageImport),
+			used:       make(map[string]string),
+			types:      make(map[string]struct{}),
+			converters: make(map[string]*sharedConverter),
+			files:      make(map[string]struct{}),
 		}
 		t.packages[pkg] = current
 	}
 	t.currentPackage = current
 }
 
+// baseName is the lower case short package name used in filenames
+func (t *packageFile) baseName() string {
+	return strings.ToLower(shortPackageName(t.name))
+}
+
+// typeFileName is allocating an unique filename, without extension,
+// for a type
+func (t *packageFile) typeFileName(typ types.Type) string {
+	name := strings.ToLower(typ.Basic().Def)
+	if idx := strings.LastIndex(name, "_"); idx != -1 && reservedFileSuffix[name[idx+1:]] {
+		name += "_type"
+	}
+	if name == t.baseName() {
+		name += "_type"
+	}
+	prefix := name
+	for idx := 2; ; idx++ {
+		if _, used := t.files[name]; !used {
+			break
+		}
+		name = fmt.Sprint(prefix, idx)
+	}
+	t.files[name] = struct{}{}
+	return name
+}
+
 func (t *packageFile) get(name string) *packageImport {
 	if ref, ok := t.imports[name]; ok {
 		return ref
@@ -0,0 +1,217 @@
+package gowasm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// sharedConverter is a package level conversion function that is
+// used instead of inline conversion code
+type sharedConverter struct {
+	name string
+	typ  string
+	toJS bool
+	code string
+}
+
+// SizeStat is source code size of a single package generated with
+// inline conversion code and with shared conversion functions.
+type SizeStat struct {
+	Package string
+
+	// Inline and Shared is size in bytes of the wasm source file
+	Inline, Shared int
+
+	// InlineLines and SharedLines is number of lines in wasm source file
+	InlineLines, SharedLines int
+
+	// Converters is number of shared conversion functions
+	Converters int
+}
+
+// sharedConvertCall is trying to replace inline conversion code with a
+// call to a shared conversion function. The function is created the
+// first time a conversion is used inside current package.
+func (b *Backend) sharedConvertCall(t types.TypeRef, info *types.TypeInfo, out, in string, use useInOut, tmpl *template.Template) (string, bool) {
+	if info.Variadic {
+		// variadic conversion is writing directly into _args
+		return "", false
+	}
+	toJS := tmpl == b.tmpl.inoutTo
+	typ, ok := sharedConvertType(info, use, toJS)
+	if !ok {
+		return "", false
+	}
+	code := b.inoutConvertCode(t, info, "_out", "_in", 0, use, tmpl)
+	if !strings.Contains(strings.TrimSpace(code), "\n") {
+		// a single line is not worth a function call
+		return "", false
+	}
+	name := b.pkgMgr.currentPackage.converter(typ, toJS, code)
+	if toJS {
+		return fmt.Sprintf("%s := %s( %s )\n", out, name, in), true
+	}
+	return fmt.Sprintf("%s = %s( %s )\n", out, name, in), true
+}
+
+// sharedConvertType is calculating the Go type that a shared conversion
+// function is using. Types that have different input and output
+// definitions are always converted inline.
+func sharedConvertType(info *types.TypeInfo, use useInOut, toJS bool) (string, bool) {
+	input, output, varIn, varOut := info.Input, info.Output, info.VarIn, info.VarOut
+	variadic := strings.HasPrefix(input, "...")
+	if variadic {
+		// inner value of a variadic parameter
+		if !toJS {
+			return "", false
+		}
+		input = input[3:]
+		output = strings.TrimPrefix(output, "[]")
+		varIn = strings.TrimPrefix(varIn, "[]")
+		varOut = strings.TrimPrefix(varOut, "[]")
+	}
+	if input != output || input != varIn || input != varOut {
+		return "", false
+	}
+	return input, true
+}
+
+// converter is returning the name of the shared conversion function
+// with given code. A new function is allocated on first usage.
+func (file *packageFile) converter(typ string, toJS bool, code string) string {
+	dir := "FromJS"
+	if toJS {
+		dir = "ToJS"
+	}
+	base := "convert" + dir + "_" + goTypeTag(typ)
+	name := base
+	for idx := 0; ; idx++ {
+		current, found := file.converters[name]
+		if !found {
+			break
+		}
+		if current.code == code {
+			return name
+		}
+		name = fmt.Sprint(base, idx)
+	}
+	file.converters[name] = &sharedConverter{
+		name: name,
+		typ:  typ,
+		toJS: toJS,
+		code: code,
+	}
+	return name
+}
+
+// converterSource is returning source code for all shared conversion
+// functions used in the package
+func (file *packageFile) converterSource() []byte {
+	list := make([]*sharedConverter, 0, len(file.converters))
+	for _, c := range file.converters {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	var out strings.Builder
+	for _, c := range list {
+		if c.toJS {
+			fmt.Fprintf(&out, "\n// %s is converting %s into a javascript value.\n", c.name, c.typ)
+			fmt.Fprintf(&out, "func %s(_in %s) interface{} {\n%s\treturn _out\n}\n", c.name, c.typ, c.code)
+		} else {
+			fmt.Fprintf(&out, "\n// %s is converting a javascript value into %s.\n", c.name, c.typ)
+			fmt.Fprintf(&out, "func %s(_in js.Value) (_out %s) {\n%s\treturn\n}\n", c.name, c.typ, c.code)
+		}
+	}
+	return []byte(out.String())
+}
+
+// goTypeTag is turning a Go type definition into a text that can be
+// used as part of a function name, e.g. []*dom.Node -> SeqPtrDom_Node
+func goTypeTag(typ string) string {
+	var out strings.Builder
+	for {
+		if strings.HasPrefix(typ, "[]") {
+			out.WriteString("Seq")
+			typ = typ[2:]
+		} else if strings.HasPrefix(typ, "*") {
+			out.WriteString("Ptr")
+			typ = typ[1:]
+		} else {
+			break
+		}
+	}
+	typ = strings.Replace(typ, "interface{}", "Any", -1)
+	typ = strings.Replace(typ, ".", "_", -1)
+	for i, c := range typ {
+		if i == 0 {
+			c = unicode.ToUpper(c)
+		}
+		out.WriteRune(c)
+	}
+	return out.String()
+}
+
+// SizeReport is generating all source code twice, with inline
+// conversion code and with shared conversion functions, and
+// return the size difference for every package. Package overrides
+// in the backend options are not used.
+func (b *Backend) SizeReport(conv *types.Convert) ([]*SizeStat, error) {
+	stats := make(map[string]*SizeStat)
+	get := func(pkg string) *SizeStat {
+		if s, found := stats[pkg]; found {
+			return s
+		}
+		s := &SizeStat{Package: pkg}
+		stats[pkg] = s
+		return s
+	}
+	opts := Options{}
+	if b.Options != nil {
+		opts = *b.Options
+	}
+	opts.Packages = nil
+	opts.SharedConvert = false
+	inlineBackend := NewBackend(&opts)
+	inlineBackend.Log = b.Log
+	inlineBackend.Templates = b.Templates
+	inline, err := inlineBackend.WriteSource(conv)
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range inline {
+		if strings.HasSuffix(src.Name, "_js.go") {
+			s := get(src.Package)
+			s.Inline += len(src.Content)
+			s.InlineLines += strings.Count(string(src.Content), "\n")
+		}
+	}
+	opts.SharedConvert = true
+	sharedBackend := NewBackend(&opts)
+	sharedBackend.Log = b.Log
+	sharedBackend.Templates = b.Templates
+	shared, err := sharedBackend.WriteSource(conv)
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range shared {
+		if strings.HasSuffix(src.Name, "_js.go") {
+			s := get(src.Package)
+			s.Shared += len(src.Content)
+			s.SharedLines += strings.Count(string(src.Content), "\n")
+			if file, found := sharedBackend.pkgMgr.packages[src.Package]; found {
+				s.Converters = len(file.converters)
+			}
+		}
+	}
+	ret := make([]*SizeStat, 0, len(stats))
+	for _, s := range stats {
+		ret = append(ret, s)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Package < ret[j].Package })
+	return ret, nil
+}
@@ -0,0 +1,227 @@
+package gowasm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// Templates is all template sets used by a backend, the default
+// templates with blocks replaced by override files
+type Templates struct {
+	file, iface, callback, dictionary, enum *template.Template
+	inoutTo, inoutFrom, fakeJS              *template.Template
+}
+
+// templateSet is a group of templates that can be overridden by
+// user supplied template files
+type templateSet struct {
+	name  string
+	input string
+	def   *template.Template
+	get   func(t *Templates) **template.Template
+}
+
+// all template sets that code generation is using. a template file
+// is named <set name>.tmpl
+var templateSets = []*templateSet{
+	{"file", fileTemplInput, fileTempl, func(t *Templates) **template.Template { return &t.file }},
+	{"interface", interfaceTmplInput, interfaceTmpl, func(t *Templates) **template.Template { return &t.iface }},
+	{"callback", callbackTmplInput, callbackTempl, func(t *Templates) **template.Template { return &t.callback }},
+	{"dictionary", dictionaryTmplInput, dictionaryTmpl, func(t *Templates) **template.Template { return &t.dictionary }},
+	{"enum", enumTmplInput, enumTempl, func(t *Templates) **template.Template { return &t.enum }},
+	{"inout-to", inoutToTmplInput, inoutToTmpl, func(t *Templates) **template.Template { return &t.inoutTo }},
+	{"inout-from", inoutFromTmplInput, inoutFromTmpl, func(t *Templates) **template.Template { return &t.inoutFrom }},
+	{"fakejs", fakeJSTmplInput, fakeJSTempl, func(t *Templates) **template.Template { return &t.fakeJS }},
+}
+
+// DefaultTemplates is returning the built in templates
+func DefaultTemplates() *Templates {
+	ret := &Templates{}
+	for _, set := range templateSets {
+		*set.get(ret) = set.def
+	}
+	return ret
+}
+
+// TemplateError contains all problems found in template override files
+type TemplateError struct {
+	Problems []string
+}
+
+func (e *TemplateError) Error() string {
+	return "template override error(s):\n\t" + strings.Join(e.Problems, "\n\t")
+}
+
+// LoadTemplates is reading template override files from a folder.
+// Every file <set>.tmpl can redefine any block from the default
+// template set with the same name. Unknown sets, unknown blocks and
+// text outside of {{define}} is reported as errors. An empty dir is
+// returning the default templates.
+func LoadTemplates(dir string) (*Templates, error) {
+	ret := DefaultTemplates()
+	if dir == "" {
+		return ret, nil
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]*templateSet)
+	for _, set := range templateSets {
+		known[set.name] = set
+	}
+	var problems []string
+	for _, fi := range files {
+		if fi.IsDir() || filepath.Ext(fi.Name()) != ".tmpl" {
+			continue
+		}
+		filename := filepath.Join(dir, fi.Name())
+		name := strings.TrimSuffix(fi.Name(), ".tmpl")
+		set, found := known[name]
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: unknown template set '%s', valid are %s",
+				filename, name, strings.Join(templateSetNames(), ", ")))
+			continue
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, set.override(ret, filename, string(content))...)
+	}
+	if len(problems) > 0 {
+		return nil, &TemplateError{Problems: problems}
+	}
+	return ret, nil
+}
+
+// override is replacing blocks of the set in given templates
+func (set *templateSet) override(tmpl *Templates, filename, content string) []string {
+	override, err := template.New(set.name).Parse(content)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %s", filename, err)}
+	}
+	base := *set.get(tmpl)
+	blocks := templateBlockNames(base)
+	// a set can have a block with the same name as the set
+	rootBlock := !isEmptyTree(base.Tree)
+	var problems []string
+	for _, t := range override.Templates() {
+		if t.Name() == set.name {
+			if !rootBlock && !isEmptyTree(t.Tree) {
+				problems = append(problems, fmt.Sprintf("%s: text outside of {{define}} blocks", filename))
+			}
+			continue
+		}
+		if base.Lookup(t.Name()) == nil {
+			problems = append(problems, fmt.Sprintf("%s: unknown block '%s', valid are %s",
+				filename, t.Name(), strings.Join(blocks, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+	merged, err := base.Clone()
+	if err == nil {
+		_, err = merged.Parse(content)
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %s", filename, err)}
+	}
+	for _, t := range override.Templates() {
+		for _, name := range templateCalls(t.Tree) {
+			if merged.Lookup(name) == nil {
+				problems = append(problems, fmt.Sprintf("%s: block '%s' is calling undefined block '%s'",
+					filename, t.Name(), name))
+			}
+		}
+	}
+	*set.get(tmpl) = merged
+	return problems
+}
+
+// templateCalls is returning all block names used by {{template}} actions
+func templateCalls(tree *parse.Tree) []string {
+	var ret []string
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case *parse.IfNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			ret = append(ret, n.Name)
+		}
+	}
+	if tree != nil {
+		walk(tree.Root)
+	}
+	return ret
+}
+
+// templateBlockNames is returning all defined blocks, sorted
+func templateBlockNames(t *template.Template) []string {
+	ret := []string{}
+	for _, b := range t.Templates() {
+		if b.Name() != t.Name() || !isEmptyTree(b.Tree) {
+			ret = append(ret, b.Name())
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func templateSetNames() []string {
+	ret := []string{}
+	for _, set := range templateSets {
+		ret = append(ret, set.name)
+	}
+	return ret
+}
+
+func isEmptyTree(tree *parse.Tree) bool {
+	if tree == nil || tree.Root == nil {
+		return true
+	}
+	for _, n := range tree.Root.Nodes {
+		text, ok := n.(*parse.TextNode)
+		if !ok || strings.TrimSpace(string(text.Text)) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTemplates is writing the default templates into a folder. It
+// is used as a starting point for template override files.
+func WriteTemplates(dir string) error {
+	if err := os.MkdirAll(dir, 0775); err != nil {
+		return err
+	}
+	for _, set := range templateSets {
+		filename := filepath.Join(dir, set.name+".tmpl")
+		if err := ioutil.WriteFile(filename, []byte(set.input), 0664); err != nil {
+			return err
+		}
+	}
+	return nil
+}
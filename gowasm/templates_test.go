@@ -0,0 +1,93 @@
+package gowasm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTemplateFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "webidl-bind-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestTemplateOverride(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"enum.tmpl": `{{define "header"}}// custom enum: {{.Basic.Idl}}
+type {{.Basic.Def}} int
+{{end}}`,
+		"readme.txt": "not a template",
+	})
+	defer os.RemoveAll(dir)
+	tmpl, err := LoadTemplates(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	conv := loadFile("testdata/enum/enum.idl", "enum", t)
+	gen := NewBackend(&Options{})
+	gen.Templates = tmpl
+	src, err := gen.WriteSource(conv)
+	assert.Nil(t, err)
+	assert.Contains(t, sourceFile("enum.go", src, t), "// custom enum: ")
+	assert.NotContains(t, sourceFile("enum.go", src, t), "ToWasmTable")
+
+	// other backends are using the default templates
+	src, err = WriteSource(conv, Options{})
+	assert.Nil(t, err)
+	assert.NotContains(t, sourceFile("enum.go", src, t), "// custom enum: ")
+}
+
+func TestTemplateOverrideFakeJS(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"fakejs.tmpl": `{{define "fakejs"}}// custom fake
+package {{.Package}}
+{{end}}`,
+	})
+	defer os.RemoveAll(dir)
+	tmpl, err := LoadTemplates(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Contains(t, templateBlockNames(tmpl.fakeJS), "fakejs")
+
+	gen := &Backend{tmpl: tmpl}
+	src, err := gen.writeFakeJS("example.com/jsfake")
+	if assert.Nil(t, err) {
+		assert.Contains(t, string(src.Content), "// custom fake")
+	}
+}
+
+func TestTemplateOverrideErrors(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"enum.tmpl":       `{{define "hello"}}{{end}}`,
+		"callback.tmpl":   `{{define "start"}}{{template "missing"}}{{end}}`,
+		"unknown.tmpl":    `{{define "header"}}{{end}}`,
+		"interface.tmpl":  `text {{define "header"}}{{end}}`,
+		"dictionary.tmpl": `{{define "header"}}{{end}`,
+	})
+	defer os.RemoveAll(dir)
+	_, err := LoadTemplates(dir)
+	if !assert.NotNil(t, err) {
+		return
+	}
+	problems := err.(*TemplateError).Problems
+	assert.Equal(t, 5, len(problems))
+	msg := err.Error()
+	assert.Contains(t, msg, "callback.tmpl: block 'start' is calling undefined block 'missing'")
+	assert.Contains(t, msg, "dictionary.tmpl: template: dictionary:1:")
+	assert.Contains(t, msg, "enum.tmpl: unknown block 'hello', valid are header")
+	assert.Contains(t, msg, "interface.tmpl: text outside of {{define}} blocks")
+	assert.Contains(t, msg, "unknown.tmpl: unknown template set 'unknown'")
+}
@@ -0,0 +1,101 @@
+package fakejs
+
+import (
+	"testing"
+
+	"github.com/gowebapi/webidl-bind/gowasm/testdata/fakejs/jsfake"
+)
+
+func newFoo() (*Foo, jsfake.Value) {
+	jsfake.Reset()
+	obj := jsfake.NewObject()
+	return FooFromJS(obj), obj
+}
+
+func TestAttribute(t *testing.T) {
+	foo, obj := newFoo()
+	foo.SetValue(42)
+	if got := foo.Value(); got != 42 {
+		t.Errorf("value: got %d, want 42", got)
+	}
+	sets := jsfake.Sets()
+	if len(sets) != 1 || sets[0].Property != "value" || !sets[0].This.Equal(obj) {
+		t.Errorf("unexpected property sets: %v", sets)
+	}
+	obj.Set("name", "foo")
+	if got := foo.Name(); got != "foo" {
+		t.Errorf("name: got %s, want foo", got)
+	}
+}
+
+func TestMethod(t *testing.T) {
+	foo, obj := newFoo()
+	obj.Set("hello", jsfake.NewFunction(func(this jsfake.Value, args []jsfake.Value) interface{} {
+		return "hello " + args[0].String()
+	}))
+	if got := foo.Hello("world"); got != "hello world" {
+		t.Errorf("hello: got '%s'", got)
+	}
+	calls := jsfake.CallsTo("hello")
+	if len(calls) != 1 || calls[0].Args[0].String() != "world" {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	foo, obj := newFoo()
+	obj.Set("list", jsfake.NewFunction(func(this jsfake.Value, args []jsfake.Value) interface{} {
+		return []interface{}{1, 2, 3}
+	}))
+	list := foo.List()
+	if len(list) != 3 || list[0] != 1 || list[2] != 3 {
+		t.Errorf("list: got %v", list)
+	}
+	obj.Set("scale", jsfake.NewFunction(func(this jsfake.Value, args []jsfake.Value) interface{} {
+		in := args[0]
+		out := make([]interface{}, in.Length())
+		for i := range out {
+			out[i] = in.Index(i).Float() * 2
+		}
+		return out
+	}))
+	scaled := foo.Scale([]float32{1, 2})
+	if len(scaled) != 2 || scaled[1] != 4 {
+		t.Errorf("scale: got %v", scaled)
+	}
+}
+
+func TestCallback(t *testing.T) {
+	foo, obj := newFoo()
+	obj.Set("apply", jsfake.NewFunction(func(this jsfake.Value, args []jsfake.Value) interface{} {
+		return args[0].Invoke(args[1])
+	}))
+	cb := HandlerToJS(func(a int) int {
+		return a * 10
+	})
+	if got := foo.Apply(cb, 4); got != 40 {
+		t.Errorf("apply: got %d", got)
+	}
+	if jsfake.FuncCount() != 1 {
+		t.Errorf("expected one allocated function")
+	}
+	jsfake.Func(*cb).Release()
+	if jsfake.FuncCount() != 0 {
+		t.Errorf("function is not released")
+	}
+}
+
+func TestException(t *testing.T) {
+	foo, obj := newFoo()
+	obj.Set("fail", jsfake.NewFunction(func(this jsfake.Value, args []jsfake.Value) interface{} {
+		jsfake.Throw("boom")
+		return nil
+	}))
+	defer func() {
+		err, ok := recover().(jsfake.Error)
+		if !ok || err.Error() != "JavaScript error: boom" {
+			t.Errorf("unexpected exception: %v", err)
+		}
+	}()
+	foo.Fail()
+}
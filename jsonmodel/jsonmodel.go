@@ -0,0 +1,308 @@
+// Package jsonmodel is an output backend that is writing the
+// converted type model as JSON files, one file per package.
+package jsonmodel
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/backend"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// Backend is writing the type model as JSON
+type Backend struct{}
+
+var _ backend.Backend = &Backend{}
+
+// Name is returning 'json'
+func (b *Backend) Name() string {
+	return "json"
+}
+
+// Description is a single line help text
+func (b *Backend) Description() string {
+	return "type model dump in JSON format"
+}
+
+type model struct {
+	Package      string        `json:"package"`
+	Enums        []*enum       `json:"enums,omitempty"`
+	Callbacks    []*callback   `json:"callbacks,omitempty"`
+	Dictionaries []*dictionary `json:"dictionaries,omitempty"`
+	Interfaces   []*iface      `json:"interfaces,omitempty"`
+}
+
+type name struct {
+	Idl string `json:"idl"`
+	Go  string `json:"go"`
+}
+
+type typeRef struct {
+	Idl      string     `json:"idl"`
+	Go       string     `json:"go"`
+	Kind     string     `json:"kind"`
+	Package  string     `json:"package,omitempty"`
+	Nullable bool       `json:"nullable,omitempty"`
+	Elem     []*typeRef `json:"elem,omitempty"`
+}
+
+type enum struct {
+	name
+	Ref    string `json:"ref"`
+	Values []name `json:"values"`
+}
+
+type param struct {
+	Name     string   `json:"name"`
+	Type     *typeRef `json:"type"`
+	Optional bool     `json:"optional,omitempty"`
+	Variadic bool     `json:"variadic,omitempty"`
+}
+
+type callback struct {
+	name
+	Ref    string   `json:"ref"`
+	Return *typeRef `json:"return"`
+	Params []*param `json:"params"`
+}
+
+type member struct {
+	name
+	Ref      string   `json:"ref"`
+	Type     *typeRef `json:"type"`
+	Required bool     `json:"required,omitempty"`
+	Readonly bool     `json:"readonly,omitempty"`
+	Static   bool     `json:"static,omitempty"`
+	Value    string   `json:"value,omitempty"`
+	Event    string   `json:"event,omitempty"`
+}
+
+type dictionary struct {
+	name
+	Ref      string    `json:"ref"`
+	Inherits string    `json:"inherits,omitempty"`
+	Members  []*member `json:"members"`
+}
+
+type method struct {
+	name
+	Ref    string   `json:"ref"`
+	Static bool     `json:"static,omitempty"`
+	Return *typeRef `json:"return"`
+	Params []*param `json:"params"`
+}
+
+type iface struct {
+	name
+	Ref         string    `json:"ref"`
+	Inherits    string    `json:"inherits,omitempty"`
+	Callback    bool      `json:"callback,omitempty"`
+	Global      bool      `json:"global,omitempty"`
+	Constructor *method   `json:"constructor,omitempty"`
+	Consts      []*member `json:"consts,omitempty"`
+	Vars        []*member `json:"vars,omitempty"`
+	Events      []*member `json:"events,omitempty"`
+	Methods     []*method `json:"methods,omitempty"`
+}
+
+// WriteSource is creating one JSON file for every package
+func (b *Backend) WriteSource(conv *types.Convert) ([]*backend.Source, error) {
+	models := make(map[string]*model)
+	get := func(t types.Type) *model {
+		pkg := t.Basic().Package
+		if m, found := models[pkg]; found {
+			return m
+		}
+		m := &model{Package: pkg}
+		models[pkg] = m
+		return m
+	}
+	for _, e := range conv.Enums {
+		if e.InUse() {
+			m := get(e)
+			m.Enums = append(m.Enums, convertEnum(e))
+		}
+	}
+	for _, cb := range conv.Callbacks {
+		if cb.InUse() {
+			m := get(cb)
+			m.Callbacks = append(m.Callbacks, convertCallback(cb))
+		}
+	}
+	for _, d := range conv.Dictionary {
+		if d.InUse() {
+			m := get(d)
+			m.Dictionaries = append(m.Dictionaries, convertDictionary(d))
+		}
+	}
+	for _, inf := range conv.Interface {
+		if inf.InUse() {
+			m := get(inf)
+			m.Interfaces = append(m.Interfaces, convertInterface(inf))
+		}
+	}
+	ret := make([]*backend.Source, 0, len(models))
+	for pkg, m := range models {
+		content, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		short := pkg
+		if idx := strings.LastIndex(pkg, "/"); idx != -1 {
+			short = pkg[idx+1:]
+		}
+		ret = append(ret, &backend.Source{
+			Package: pkg,
+			Name:    strings.ToLower(short) + ".json",
+			Content: append(content, '\n'),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Package < ret[j].Package })
+	return ret, nil
+}
+
+func typeName(basic types.BasicInfo) name {
+	return name{Idl: basic.Idl, Go: basic.Def}
+}
+
+func convertType(t types.TypeRef) *typeRef {
+	info, inner := t.DefaultParam()
+	ret := &typeRef{
+		Idl:      info.Idl,
+		Go:       info.Input,
+		Kind:     info.Template,
+		Nullable: info.Nullable,
+	}
+	if info.Package != types.BuiltInPackage {
+		ret.Package = info.Package
+	}
+	var elems []types.TypeRef
+	switch inner := inner.(type) {
+	case *types.SequenceType:
+		elems = []types.TypeRef{inner.Elem}
+	case *types.TypedArrayType:
+		elems = []types.TypeRef{inner.Elem}
+	case *types.UnionType:
+		elems = inner.Types
+	case *types.ParametrizedType:
+		elems = inner.Elems
+	}
+	for _, e := range elems {
+		ret.Elem = append(ret.Elem, convertType(e))
+	}
+	return ret
+}
+
+func convertParams(list []*types.Parameter) []*param {
+	ret := []*param{}
+	for _, p := range list {
+		ret = append(ret, &param{
+			Name:     p.Name,
+			Type:     convertType(p.Type),
+			Optional: p.Optional,
+			Variadic: p.Variadic,
+		})
+	}
+	return ret
+}
+
+func convertEnum(e *types.Enum) *enum {
+	ret := &enum{
+		name:   typeName(e.Basic()),
+		Ref:    e.SourceReference().String(),
+		Values: []name{},
+	}
+	for _, v := range e.Values {
+		ret.Values = append(ret.Values, name{Idl: v.Idl, Go: e.Prefix + v.Def + e.Suffix})
+	}
+	return ret
+}
+
+func convertCallback(cb *types.Callback) *callback {
+	return &callback{
+		name:   typeName(cb.Basic()),
+		Ref:    cb.SourceReference().String(),
+		Return: convertType(cb.Return),
+		Params: convertParams(cb.Parameters),
+	}
+}
+
+func convertDictionary(d *types.Dictionary) *dictionary {
+	ret := &dictionary{
+		name:    typeName(d.Basic()),
+		Ref:     d.SourceReference().String(),
+		Members: []*member{},
+	}
+	if d.Inherits != nil {
+		ret.Inherits = d.Inherits.Basic().Idl
+	}
+	for _, m := range d.Members {
+		ret.Members = append(ret.Members, &member{
+			name:     name{Idl: m.Name().Idl, Go: m.Name().Def},
+			Ref:      m.SourceReference().String(),
+			Type:     convertType(m.Type),
+			Required: m.Required,
+		})
+	}
+	return ret
+}
+
+func convertMethod(m *types.IfMethod) *method {
+	return &method{
+		name:   name{Idl: m.Name().Idl, Go: m.Name().Def},
+		Ref:    m.SourceReference().String(),
+		Static: m.Static,
+		Return: convertType(m.Return),
+		Params: convertParams(m.Params),
+	}
+}
+
+func convertVar(v *types.IfVar) *member {
+	return &member{
+		name:     name{Idl: v.Name().Idl, Go: v.Name().Def},
+		Ref:      v.SourceReference().String(),
+		Type:     convertType(v.Type),
+		Readonly: v.Readonly,
+		Static:   v.Static,
+		Event:    v.EventName,
+	}
+}
+
+func convertInterface(inf *types.Interface) *iface {
+	ret := &iface{
+		name:     typeName(inf.Basic()),
+		Ref:      inf.SourceReference().String(),
+		Callback: inf.Callback,
+		Global:   inf.Global,
+	}
+	if inf.Inherits != nil {
+		ret.Inherits = inf.Inherits.Basic().Idl
+	}
+	if inf.Constructor != nil {
+		ret.Constructor = convertMethod(inf.Constructor)
+	}
+	for _, c := range inf.Consts {
+		ret.Consts = append(ret.Consts, &member{
+			name:  name{Idl: c.Name().Idl, Go: inf.ConstPrefix + c.Name().Def + inf.ConstSuffix},
+			Ref:   c.SourceReference().String(),
+			Type:  convertType(c.Type),
+			Value: c.Value,
+		})
+	}
+	for _, list := range [][]*types.IfVar{inf.StaticVars, inf.Vars} {
+		for _, v := range list {
+			ret.Vars = append(ret.Vars, convertVar(v))
+		}
+	}
+	for _, ev := range inf.Events {
+		ret.Events = append(ret.Events, convertVar(ev))
+	}
+	for _, list := range [][]*types.IfMethod{inf.StaticMethod, inf.Method} {
+		for _, m := range list {
+			ret.Methods = append(ret.Methods, convertMethod(m))
+		}
+	}
+	return ret
+}
@@ -0,0 +1,53 @@
+package jsonmodel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+func TestWriteSource(t *testing.T) {
+	conv := types.NewConvert()
+	setup := &types.Setup{
+		Error: func(ref types.GetRef, format string, args ...interface{}) {
+			t.Errorf(format, args...)
+		},
+		Warning:  func(ref types.GetRef, format string, args ...interface{}) {},
+		Filename: "model.idl",
+		Package:  "model",
+	}
+	idl := `
+enum Color { "red", "dark-blue" };
+dictionary Point { long x; long y; };
+callback Done = void (sequence<Point> points);
+interface Canvas {
+	attribute Color color;
+	void draw(Point p, optional Done done);
+};
+`
+	if !assert.Nil(t, conv.Parse([]byte(idl), setup)) || !assert.Nil(t, conv.Evaluate()) {
+		return
+	}
+	src, err := (&Backend{}).WriteSource(conv)
+	if !assert.Nil(t, err) || !assert.Equal(t, 1, len(src)) {
+		return
+	}
+	assert.Equal(t, "model/model.json", src[0].Package+"/"+src[0].Name)
+
+	var m model
+	if !assert.Nil(t, json.Unmarshal(src[0].Content, &m)) {
+		return
+	}
+	assert.Equal(t, "model", m.Package)
+	assert.Equal(t, 1, len(m.Enums))
+	assert.Equal(t, "DarkBlueColor", m.Enums[0].Values[1].Go)
+	assert.Equal(t, 1, len(m.Callbacks))
+	assert.Equal(t, "sequence", m.Callbacks[0].Params[0].Type.Kind)
+	assert.Equal(t, "Point", m.Callbacks[0].Params[0].Type.Elem[0].Idl)
+	assert.Equal(t, 1, len(m.Interfaces))
+	assert.Equal(t, "Draw", m.Interfaces[0].Methods[0].Go)
+	assert.True(t, m.Interfaces[0].Methods[0].Params[1].Optional)
+}
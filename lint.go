@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/transform"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+func runLint() error {
+	// only findings on stdout
+	p, err := loadInput(os.Stderr)
+	if err != nil {
+		return err
+	}
+	if err := p.trans.Execute(p.conv); err != nil {
+		return err
+	}
+	list := p.trans.Lint()
+	list = append(list, lintMemberNames(p.conv)...)
+	transform.RenameOverrideMethods(p.conv)
+	p.conv.Sort()
+	gen, err := gowasmBackend()
+	if err != nil {
+		return err
+	}
+	gen.Log = os.Stderr
+	clashes, err := lintGoNames(p.conv, gen)
+	if err != nil {
+		return err
+	}
+	list = append(list, clashes...)
+	return printLint(os.Stdout, list)
+}
+
+func printLint(dst io.Writer, list []*transform.LintMessage) error {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Filename != list[j].Filename {
+			return list[i].Filename < list[j].Filename
+		}
+		return list[i].Line < list[j].Line
+	})
+	for _, msg := range list {
+		fmt.Fprintln(dst, msg)
+	}
+	if len(list) > 0 {
+		return fmt.Errorf("lint found %d problem(s)", len(list))
+	}
+	return nil
+}
+
+// lintGoNames is generating the source code and report package
+// level Go declarations that is produced by more than one type
+func lintGoNames(conv *types.Convert, gen *gowasm.Backend) ([]*transform.LintMessage, error) {
+	files, err := gen.WriteSource(conv)
+	if err != nil {
+		return nil, err
+	}
+	ret := []*transform.LintMessage{}
+	seen := make(map[string]bool)
+	reported := make(map[string]bool)
+	for _, src := range files {
+		if !strings.HasSuffix(src.Name, "_js.go") {
+			continue
+		}
+		for _, entry := range gen.LineTable(src).Entries {
+			// members are checked by lintMemberNames
+			if strings.Contains(entry.Decl, ".") {
+				continue
+			}
+			key := src.Package + ":" + entry.Decl
+			if !seen[key] {
+				seen[key] = true
+				continue
+			}
+			msg := &transform.LintMessage{
+				Filename: src.Name,
+				Line:     entry.From,
+				Text:     fmt.Sprintf("Go name '%s' is declared more than once in package %s", entry.Decl, src.Package),
+			}
+			if entry.Ref != nil {
+				msg.Filename, msg.Line = entry.Ref.Filename, entry.Ref.Line
+			}
+			// a type is having several declarations, only report first
+			at := fmt.Sprint(msg.Filename, ":", msg.Line)
+			if !reported[at] {
+				reported[at] = true
+				ret = append(ret, msg)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// lintMemberNames is reporting members of a type that get the same
+// Go name. must be done before RenameOverrideMethods as it's adding
+// a number to duplicate method names
+func lintMemberNames(conv *types.Convert) []*transform.LintMessage {
+	ret := []*transform.LintMessage{}
+	for _, value := range conv.All {
+		if !value.InUse() || !value.TypeID().IsPublic() {
+			continue
+		}
+		first := make(map[string]*explained)
+		for _, m := range findMembers(value, func(*types.MethodName) bool { return true }) {
+			if m.kind == "event" {
+				continue
+			}
+			key := m.name.Def
+			if strings.HasPrefix(m.kind, "static") || m.kind == "const" {
+				key = m.kind + ":" + key
+			}
+			prev, found := first[key]
+			if !found {
+				first[key] = m
+				continue
+			}
+			if prev.name.Idl == m.name.Idl {
+				// overloaded method
+				continue
+			}
+			text := fmt.Sprintf("Go name '%s.%s' of %s '%s'%s is also used by '%s' at %s%s",
+				value.Basic().Def, m.name.Def, m.kind, m.name.Idl, renamedBy(m.ref),
+				prev.name.Idl, prev.ref, renamedBy(prev.ref))
+			ret = append(ret, &transform.LintMessage{
+				Filename: m.ref.Filename,
+				Line:     m.ref.Line,
+				Text:     text,
+			})
+		}
+	}
+	return ret
+}
+
+func renamedBy(ref *types.Ref) string {
+	if ref.RenamedBy == "" {
+		return ""
+	}
+	return " (renamed by " + ref.RenamedBy + ")"
+}
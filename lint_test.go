@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintIdl = `
+callback PromiseCallback = void(any value);
+interface Promise { };
+interface Store {
+  long getCount();
+  long count();
+  void put(Blob b);
+  void click();
+  void click(long x);
+};
+interface Blob { };
+`
+
+const lintTransform = `# lint
+
+.title = internal
+
+@on interface "^NoSuchType": .namespace = foo
+
+## Store
+
+getCount = Count
+`
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	idl := filepath.Join(dir, "lint.idl")
+	md := filepath.Join(dir, "lint.md")
+	assert.Nil(t, ioutil.WriteFile(idl, []byte(lintIdl), 0664))
+	assert.Nil(t, ioutil.WriteFile(md, []byte(lintTransform), 0664))
+	saved := args
+	defer func() { args = saved }()
+	args.inputs = []string{idl, md}
+	args.singlePkg = ""
+
+	p, err := loadInput(ioutil.Discard)
+	if !assert.Nil(t, err) {
+		return
+	}
+	if !assert.Nil(t, p.trans.Execute(p.conv)) {
+		return
+	}
+	list := p.trans.Lint()
+	list = append(list, lintMemberNames(p.conv)...)
+	var out strings.Builder
+	assert.NotNil(t, printLint(&out, list))
+	assert.Equal(t, idl+":6: Go name 'Store.Count' of method 'count' is also used by 'getCount' at "+
+		idl+":5 (renamed by "+md+":9)\n"+
+		md+":5: regexp rule '^NoSuchType' doesn't match any type\n", out.String())
+
+	out.Reset()
+	assert.Nil(t, printLint(&out, nil))
+	assert.Empty(t, out.String())
+}
@@ -4,16 +4,14 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"runtime/pprof"
-	"sort"
 	"strings"
 
+	"github.com/gowebapi/webidl-bind/backend"
 	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/jsonmodel"
 	"github.com/gowebapi/webidl-bind/transform"
 	"github.com/gowebapi/webidl-bind/types"
 	"github.com/gowebapi/webidl-bind/zinfo"
@@ -29,6 +27,19 @@ var args struct {
 	statusFile string
 	crossRef   string
 	cpuProfile string
+	gowasm     gowasm.Options
+	sizeReport bool
+	target     string
+	templates  string
+	writeTmpl  string
+	force      bool
+	diff       bool
+	check      bool
+	fmtList    bool
+	config     string
+	inputs     []string
+	command    *command
+	commandArg string
 }
 
 var errStop = errors.New("too many errors")
@@ -49,194 +60,12 @@ func main() {
 		}
 		defer pprof.StopCPUProfile()
 	}
-	if err := run(); err != nil {
+	if err := args.command.run(); err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
-	if fi, err := os.Stat(args.outputPath); err != nil {
-		return fmt.Errorf("trouble evaluate %s: %s", args.outputPath, err)
-	} else if !fi.IsDir() {
-		return fmt.Errorf("output path '%s' doesn't point to a directory", args.outputPath)
-	}
-
-	trans := transform.New()
-	conv := types.NewConvert()
-	setup := &types.Setup{
-		Package: args.singlePkg,
-		Error:   failing,
-		Warning: warning,
-	}
-
-	for _, name := range flag.Args() {
-		ext := filepath.Ext(name)
-		if ext == ".md" {
-			fmt.Println("reading modificaton file", name)
-			pkg := gowasm.FormatPkg(name, args.singlePkg)
-			if err := trans.Load(name, pkg); err != nil {
-				return err
-			}
-		} else if ext == ".idl" {
-			fmt.Println("reading WebIDL file", name)
-			if err := processFile(name, conv, setup); err != nil {
-				return err
-			}
-		} else {
-			fmt.Println("skipping", name)
-		}
-	}
-	if err := conv.Evaluate(); err != nil {
-		return err
-	}
-	if err := trans.Execute(conv); err != nil {
-		return err
-	}
-	transform.RenameOverrideMethods(conv)
-	conv.Sort()
-
-	files, err := gowasm.WriteSource(conv)
-	if err != nil {
-		return err
-	}
-
-	folders := []string{}
-	for _, src := range files {
-		filename, inc := src.Filename(args.insidePkg)
-		if !inc {
-			fmt.Printf("skipping '%s' as we are inside '%s'\n", src.Package, args.insidePkg)
-			continue
-		}
-		path := filepath.Join(args.outputPath, filename)
-		dir := filepath.Dir(path)
-		folders = append(folders, dir)
-		if !pathExist(dir) {
-			fmt.Println("creating folder", dir)
-			if err := os.MkdirAll(dir, 0775); err != nil {
-				return err
-			}
-		}
-		fmt.Println("saving ", path)
-		if err := ioutil.WriteFile(path, src.Content, 0666); err != nil {
-			return err
-		}
-	}
-	if args.statusFile != "" {
-		if err := trans.WriteMarkdownStatus(args.statusFile); err != nil {
-			return err
-		}
-	}
-	if args.crossRef != "" {
-		if err := trans.WriteCrossReference(args.crossRef); err != nil {
-			return err
-		}
-	}
-	if err := tryCompileResult(folders); err != nil {
-		return err
-	}
-	if err := tryTestResult(folders); err != nil {
-		return err
-	}
-	return nil
-}
-
-func processFile(filename string, conv *types.Convert, setup *types.Setup) error {
-	setup.Package = gowasm.FormatPkg(filename, args.singlePkg)
-	setup.Filename = filename
-	if err := conv.Load(setup); err != nil {
-		return err
-	}
-	return nil
-}
-
-func tryCompileResult(folders []string) error {
-	if args.goBuild == "" {
-		return nil
-	}
-	sort.Strings(folders)
-	last := ":/:"
-	failed := []string{}
-	for _, folder := range folders {
-		if folder == last {
-			continue
-		}
-		last = folder
-
-		wasm := args.goBuild == "wasm"
-		args := []string{"build"}
-		if !wasm {
-			args = append(args, "-i")
-		}
-
-		p := exec.Command("go", args...)
-		p.Dir = folder
-		p.Stdout = os.Stdout
-		p.Stderr = os.Stderr
-		if wasm {
-			p.Env = os.Environ()
-			p.Env = append(p.Env, "GOOS=js")
-			p.Env = append(p.Env, "GOARCH=wasm")
-		}
-		fmt.Printf("> running '%s' in folder %s\n", strings.Join(p.Args, " "), folder)
-		if err := p.Run(); err != nil {
-			fmt.Println("> error: command failed:", err)
-			failed = append(failed, folder)
-		}
-	}
-	if len(failed) > 0 {
-		return fmt.Errorf("not all building was successful. failure in %s", strings.Join(failed, ", "))
-	}
-	return nil
-}
-
-func tryTestResult(folders []string) error {
-	if args.goTest == "" {
-		return nil
-	}
-	sort.Strings(folders)
-	last := ":/:"
-	failed := []string{}
-	for _, folder := range folders {
-		if folder == last {
-			continue
-		}
-		last = folder
-
-		// any test files?
-		if yes, err := haveTestFiles(folder); err != nil {
-			return err
-		} else if !yes {
-			continue
-		}
-
-		wasm := args.goTest == "wasm"
-		args := []string{"test"}
-		// if !wasm {
-		// 	args = append(args, "-i")
-		// }
-
-		p := exec.Command("go", args...)
-		p.Dir = folder
-		p.Stdout = os.Stdout
-		p.Stderr = os.Stderr
-		if wasm {
-			p.Env = os.Environ()
-			p.Env = append(p.Env, "GOOS=js")
-			p.Env = append(p.Env, "GOARCH=wasm")
-		}
-		fmt.Printf("> running '%s' in folder %s\n", strings.Join(p.Args, " "), folder)
-		if err := p.Run(); err != nil {
-			fmt.Println("> error: command failed:", err)
-			failed = append(failed, folder)
-		}
-	}
-	if len(failed) > 0 {
-		return fmt.Errorf("not all test was successful. failure in %s", strings.Join(failed, ", "))
-	}
-	return nil
-}
-
 func failing(ref types.GetRef, format string, args ...interface{}) {
 	source := ""
 	if ref != nil {
@@ -265,27 +94,90 @@ func warning(ref types.GetRef, format string, values ...interface{}) {
 }
 
 func parseArgs() string {
+	backend.Register(gowasm.NewBackend(&args.gowasm))
+	backend.Register(&jsonmodel.Backend{})
+	targets := []string{}
+	for _, b := range backend.List() {
+		targets = append(targets, fmt.Sprintf("%s (%s)", b.Name(), b.Description()))
+	}
+	flag.StringVar(&args.target, "target", "gowasm", "output backend: "+strings.Join(targets, ", "))
+	flag.StringVar(&args.config, "config", "", "project configuration `file`, default is "+defaultConfigFile+" if no input files are given")
 	flag.BoolVar(&args.warnings, "log-warning", true, "log warnings")
 	flag.StringVar(&args.outputPath, "output", "", "output path")
+	flag.BoolVar(&args.force, "force", false, "overwrite and remove generated files that have been edited by hand, and existing files not written by a previous run")
+	flag.BoolVar(&args.diff, "diff", false, "print a unified diff against current output instead of writing files, exit with failure if different")
 	flag.StringVar(&args.insidePkg, "inside-package", "", "output path is inside current package")
 	flag.StringVar(&args.singlePkg, "single-package", "", "all types to same package")
 	flag.StringVar(&args.goBuild, "go-build", "", "execute go build in output folders")
+	flag.BoolVar(&args.check, "check", false, "type check generated packages for both wasm and host")
 	flag.StringVar(&args.goTest, "go-test", "", "execute go test in output folders")
 	flag.StringVar(&args.statusFile, "spec-status", "", "write a markdown spec status file")
+	flag.BoolVar(&args.fmtList, "l", false, "fmt: list transform files that aren't formatted instead of rewriting them")
 	flag.StringVar(&args.crossRef, "cross-ref", "", "write a javascript go type cross reference file")
+	flag.BoolVar(&transform.Verbose, "verbose", false, "print extra information, e.g. types matched by wildcard type headers")
 	flag.StringVar(&args.cpuProfile, "cpuprofile", "", "write cpu profile to `file`")
+	flag.BoolVar(&args.gowasm.SharedConvert, "shared-convert", false, "use shared conversion functions instead of inline conversion code")
+	flag.BoolVar(&args.sizeReport, "size-report", false, "print source code size with and without shared conversion functions")
+	flag.BoolVar(&args.gowasm.CallbackScope, "callback-scope", false, "generate self releasing callback allocation functions")
+	flag.BoolVar(&args.gowasm.FilePerType, "file-per-type", false, "write every type into its own file instead of one file per package")
+	flag.BoolVar(&args.gowasm.LineDirectives, "line-directives", false, "add //line directives pointing generated code to WebIDL source")
+	flag.StringVar(&args.gowasm.FakeJS, "fake-js", "", "generate a fake javascript runtime `package` used in host builds")
+	flag.StringVar(&args.templates, "templates", "", "folder with `<set>.tmpl` files overriding gowasm template blocks")
+	flag.StringVar(&args.writeTmpl, "write-templates", "", "write default gowasm templates into `folder` and exit")
 	license := flag.Bool("license", false, "print license information")
-	flag.Parse()
+	flag.Usage = usage
+	args.command = commands[0]
+	cmdArgs := os.Args[1:]
+	if len(cmdArgs) > 0 {
+		if cmd := findCommand(cmdArgs[0]); cmd != nil {
+			args.command = cmd
+			cmdArgs = cmdArgs[1:]
+		}
+	}
+	flag.CommandLine.Parse(cmdArgs)
 	if *license {
 		zinfo.PrinLicenseText()
 		os.Exit(0)
 	}
-	if len(flag.Args()) == 0 {
+	if args.writeTmpl != "" {
+		return ""
+	}
+	args.inputs = flag.Args()
+	if args.command.argument != "" {
+		if len(args.inputs) == 0 {
+			return fmt.Sprintf("missing %s argument to %s", args.command.argument, args.command.name)
+		}
+		args.commandArg = args.inputs[0]
+		args.inputs = args.inputs[1:]
+	}
+	if args.config == "" && len(args.inputs) == 0 && pathExist(defaultConfigFile) {
+		args.config = defaultConfigFile
+	}
+	if args.config != "" {
+		fmt.Fprintln(os.Stderr, "reading configuration file", args.config)
+		conf, err := loadConfig(args.config)
+		if err != nil {
+			return err.Error()
+		}
+		if err := conf.apply(flagsOnCommandLine()); err != nil {
+			return err.Error()
+		}
+	}
+	if len(args.inputs) == 0 {
 		return "no input files on command line"
 	}
-	if args.outputPath == "" {
+	if args.outputPath == "" && args.command.needOutput {
 		return "missing output path for file(s)"
 	}
+	if _, found := backend.Get(args.target); !found {
+		return fmt.Sprintf("unknown -target '%s', valid are %s", args.target, strings.Join(backend.Names(), ", "))
+	}
+	if args.diff && (args.goBuild != "" || args.goTest != "" || args.check || args.statusFile != "" || args.crossRef != "") {
+		return "-diff can't be combined with -go-build, -go-test, -check, -spec-status or -cross-ref"
+	}
+	if args.target != "gowasm" && (args.goBuild != "" || args.goTest != "" || args.check) {
+		return "-go-build, -go-test and -check is only valid with gowasm target"
+	}
 	if args.goBuild != "" && args.goBuild != "wasm" && args.goBuild != "host" {
 		return "-go-build value should be 'wasm' or 'host'"
 	}
@@ -295,22 +187,22 @@ func parseArgs() string {
 	return ""
 }
 
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "usage: %s [command] [flags] [argument] [input files]\n\ncommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.usage)
+		if cmd.argument != "" {
+			fmt.Fprintf(out, "  %-10s argument: %s\n", "", cmd.argument)
+		}
+	}
+	fmt.Fprintln(out, "\nflags:")
+	flag.PrintDefaults()
+}
+
 func pathExist(path string) bool {
 	if _, err := os.Stat(path); err == nil {
 		return true
 	}
 	return false
 }
-
-func haveTestFiles(path string) (bool, error) {
-	files, err := ioutil.ReadDir(path)
-	if err != nil {
-		return false, err
-	}
-	for _, f := range files {
-		if strings.HasSuffix(f.Name(), "_test.go") {
-			return true, nil
-		}
-	}
-	return false, nil
-}
@@ -0,0 +1,262 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// number of unchanged lines around every change
+const diffContext = 3
+
+// Diff is writing a unified diff between files in output root and
+// given files, without changing anything on disk. Stale files in the
+// manifest are shown as removed. True is returned if there is any
+// difference.
+func (w *Writer) Diff(files []*File, dst io.Writer) (bool, error) {
+	old, _, err := readManifest(w.manifestPath())
+	if err != nil {
+		return false, err
+	}
+	differ := false
+	generated := make(map[string]bool)
+	for _, f := range files {
+		generated[f.Name] = true
+		current, exist, err := w.readFile(f.Name)
+		if err != nil {
+			return false, err
+		}
+		from := "a/" + f.Name
+		if !exist {
+			from = "/dev/null"
+		}
+		if UnifiedDiff(dst, from, "b/"+f.Name, string(current), string(f.Content)) {
+			differ = true
+		}
+	}
+	for _, name := range sortedKeys(old) {
+		if generated[name] {
+			continue
+		}
+		current, exist, err := w.readFile(name)
+		if err != nil {
+			return false, err
+		}
+		if exist && UnifiedDiff(dst, "a/"+name, "/dev/null", string(current), "") {
+			differ = true
+		}
+	}
+	return differ, nil
+}
+
+func (w *Writer) readFile(name string) ([]byte, bool, error) {
+	content, err := ioutil.ReadFile(filepath.Join(w.Root, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	return content, err == nil, err
+}
+
+// diffOp is a single line in an edit script
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// UnifiedDiff is writing a unified diff between two texts. Nothing is
+// written if both texts are equal, and false is returned.
+func UnifiedDiff(dst io.Writer, fromName, toName, from, to string) bool {
+	if from == to {
+		return false
+	}
+	ops := diffLines(splitLines(from), splitLines(to))
+	fmt.Fprintf(dst, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(ops); {
+		// find next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		begin := start - diffContext
+		if begin < 0 {
+			begin = 0
+		}
+		// extend hunk until there is enough unchanged lines
+		end := start
+		for same := 0; end < len(ops) && same <= 2*diffContext; end++ {
+			if ops[end].kind == ' ' {
+				same++
+			} else {
+				same = 0
+			}
+		}
+		for end > start && ops[end-1].kind == ' ' {
+			end--
+		}
+		end += diffContext
+		if end > len(ops) {
+			end = len(ops)
+		}
+		writeHunk(dst, ops, begin, end)
+		start = end
+	}
+	return true
+}
+
+func writeHunk(dst io.Writer, ops []diffOp, begin, end int) {
+	fromLine, toLine := 1, 1
+	for _, op := range ops[:begin] {
+		if op.kind != '+' {
+			fromLine++
+		}
+		if op.kind != '-' {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	for _, op := range ops[begin:end] {
+		if op.kind != '+' {
+			fromCount++
+		}
+		if op.kind != '-' {
+			toCount++
+		}
+	}
+	fmt.Fprintf(dst, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+	for _, op := range ops[begin:end] {
+		line := op.line
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n\\ No newline at end of file\n"
+		}
+		fmt.Fprintf(dst, "%c%s", op.kind, line)
+	}
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		// an empty range is referring to the line before
+		return fmt.Sprintf("%d,0", line-1)
+	}
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// splitLines is splitting text into lines, including the newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines is calculating an edit script using Myers' algorithm
+func diffLines(a, b []string) []diffOp {
+	// common prefix and suffix doesn't need any work
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func myers(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	// only the diagonals -d..d is saved for every step, that is all
+	// that the backtrack is using
+	trace := [][]int{}
+	for d := 0; d <= max; d++ {
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return myersBacktrack(a, b, trace)
+			}
+		}
+	}
+	return nil
+}
+
+// myersBacktrack is walking the saved traces backwards to get the
+// edit script
+func myersBacktrack(a, b []string, trace [][]int) []diffOp {
+	x, y := len(a), len(b)
+	var rev []diffOp
+	for d := len(trace) - 1; d >= 0; d-- {
+		window := trace[d]
+		v := func(k int) int {
+			if k < -d || k > d {
+				// outside of saved diagonals, only happening at start
+				return 0
+			}
+			return window[k+d]
+		}
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			rev = append(rev, diffOp{' ', a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				rev = append(rev, diffOp{'+', b[y]})
+			} else {
+				x--
+				rev = append(rev, diffOp{'-', a[x]})
+			}
+		}
+	}
+	ops := make([]diffOp, len(rev))
+	for i, op := range rev {
+		ops[len(rev)-1-i] = op
+	}
+	return ops
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	var out strings.Builder
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n"
+	assert.True(t, UnifiedDiff(&out, "a/x", "b/x", from, to))
+	assert.Equal(t, `--- a/x
++++ b/x
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,3 +11,4 @@
+ k
+ l
+ m
++n
+`, out.String())
+
+	out.Reset()
+	assert.False(t, UnifiedDiff(&out, "a/x", "b/x", from, from))
+	assert.Equal(t, "", out.String())
+}
+
+func TestUnifiedDiffMerge(t *testing.T) {
+	var out strings.Builder
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	to := "0\n2\n3\n4\n5\n6\n7\n8"
+	assert.True(t, UnifiedDiff(&out, "a/x", "b/x", from, to))
+	assert.Equal(t, `--- a/x
++++ b/x
+@@ -1,8 +1,8 @@
+-1
++0
+ 2
+ 3
+ 4
+ 5
+ 6
+ 7
+-8
++8
+\ No newline at end of file
+`, out.String())
+}
+
+func TestUnifiedDiffNewFile(t *testing.T) {
+	var out strings.Builder
+	assert.True(t, UnifiedDiff(&out, "/dev/null", "b/x", "", "a\nb\n"))
+	assert.Equal(t, "--- /dev/null\n+++ b/x\n@@ -0,0 +1,2 @@\n+a\n+b\n", out.String())
+}
+
+func TestWriterDiff(t *testing.T) {
+	w := &Writer{Root: t.TempDir()}
+	_, err := w.Write([]*File{
+		{Name: "a/a.go", Content: []byte("package a\n")},
+		{Name: "b/b.go", Content: []byte("package b\n")},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	files := []*File{
+		{Name: "a/a.go", Content: []byte("package a\n")},
+		{Name: "c/c.go", Content: []byte("package c\n")},
+	}
+	var out strings.Builder
+	differ, err := w.Diff(files, &out)
+	assert.Nil(t, err)
+	assert.True(t, differ)
+	assert.Equal(t, `--- /dev/null
++++ b/c/c.go
+@@ -0,0 +1 @@
++package c
+--- a/b/b.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package b
+`, out.String())
+
+	// nothing is changed on disk
+	_, err = ioutil.ReadFile(filepath.Join(w.Root, "c", "c.go"))
+	assert.NotNil(t, err)
+	assert.FileExists(t, filepath.Join(w.Root, "b", "b.go"))
+
+	out.Reset()
+	differ, err = w.Diff(files[:1], &out)
+	assert.Nil(t, err)
+	assert.True(t, differ)
+
+	_, err = w.Write(files)
+	assert.Nil(t, err)
+	out.Reset()
+	differ, err = w.Diff(files, &out)
+	assert.Nil(t, err)
+	assert.False(t, differ)
+	assert.Equal(t, "", out.String())
+}
@@ -0,0 +1,265 @@
+// Package output is writing generated files into an output folder.
+// Only files with a changed content is written, and a manifest with
+// all generated files is used to remove files that isn't generated
+// any more and to detect generated files that have been edited by hand.
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestName is the filename of the manifest in the output root,
+// used when the writer has no target
+const ManifestName = ".webidl-bind.manifest"
+
+// ManifestFile is the filename of the manifest for given target. Every
+// target has its own manifest so several targets can share output root
+// without removing each other's files.
+func ManifestFile(target string) string {
+	if target == "" {
+		return ManifestName
+	}
+	return ".webidl-bind." + target + ".manifest"
+}
+
+const manifestHeader = "# Generated by webidl-bind, DO NOT EDIT. sha256 and filename of all generated files.\n"
+
+// File is a single file that should be written
+type File struct {
+	// Name is a slash separated filename relative to output root
+	Name string
+
+	// Content is the file content
+	Content []byte
+}
+
+// Writer is writing files into an output root
+type Writer struct {
+	// Root is the output root folder
+	Root string
+
+	// Target is the backend writing the files, selecting the manifest
+	Target string
+
+	// Force is overwriting and removing hand edited files
+	Force bool
+
+	// Log is called for every action, can be nil
+	Log func(format string, args ...interface{})
+}
+
+// Conflict is a generated file that have been modified after it
+// was written
+type Conflict struct {
+	Name string
+
+	// Stale is true if the file isn't generated any more
+	Stale bool
+
+	// Unknown is true if the file isn't in the manifest, it's not
+	// written by a previous run
+	Unknown bool
+}
+
+func (c *Conflict) String() string {
+	if c.Unknown {
+		return c.Name + ": file already exist and isn't generated by a previous run"
+	}
+	if c.Stale {
+		return c.Name + ": generated file is edited by hand and no longer generated"
+	}
+	return c.Name + ": generated file is edited by hand"
+}
+
+// Result is a summary of what a write did
+type Result struct {
+	Written   []string
+	Unchanged []string
+	Removed   []string
+	Conflicts []*Conflict
+}
+
+// Write is updating the output root with given files. Files with
+// same content is not touched. Files listed in the previous manifest
+// but not in files are removed. Files that have been modified since
+// last run, and existing files that isn't in the manifest, is
+// reported as conflicts and is left unchanged. Existing files with
+// same content as generated are adopted into the manifest.
+func (w *Writer) Write(files []*File) (*Result, error) {
+	old, hasManifest, err := readManifest(w.manifestPath())
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	manifest := make(map[string]string)
+	for _, f := range files {
+		hash := hashContent(f.Content)
+		path := filepath.Join(w.Root, filepath.FromSlash(f.Name))
+		current, err := ioutil.ReadFile(path)
+		exist := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if exist && bytes.Equal(current, f.Content) {
+			result.Unchanged = append(result.Unchanged, f.Name)
+			manifest[f.Name] = hash
+			continue
+		}
+		if exist && !w.Force {
+			// only overwrite files with same content as last run
+			if prev, found := old[f.Name]; !found || prev != hashContent(current) {
+				result.Conflicts = append(result.Conflicts, &Conflict{Name: f.Name, Unknown: !found})
+				if found {
+					manifest[f.Name] = prev
+				}
+				continue
+			}
+		}
+		if err := w.writeFile(path, f.Content); err != nil {
+			return nil, err
+		}
+		result.Written = append(result.Written, f.Name)
+		manifest[f.Name] = hash
+	}
+
+	// remove files that isn't generated any more
+	for _, name := range sortedKeys(old) {
+		if _, found := manifest[name]; found {
+			continue
+		}
+		if conflict, err := w.removeFile(name, old[name]); err != nil {
+			return nil, err
+		} else if conflict {
+			result.Conflicts = append(result.Conflicts, &Conflict{Name: name, Stale: true})
+			manifest[name] = old[name]
+		} else {
+			result.Removed = append(result.Removed, name)
+		}
+	}
+	if err := w.writeManifest(manifest, old, hasManifest); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (w *Writer) writeFile(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		w.log("creating folder %s", dir)
+		if err := os.MkdirAll(dir, 0775); err != nil {
+			return err
+		}
+	}
+	w.log("saving %s", path)
+	return ioutil.WriteFile(path, content, 0666)
+}
+
+// removeFile is removing a stale file. True is returned if the
+// file was edited by hand and therefore not removed.
+func (w *Writer) removeFile(name, hash string) (bool, error) {
+	path := filepath.Join(w.Root, filepath.FromSlash(name))
+	current, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if !w.Force && hashContent(current) != hash {
+		return true, nil
+	}
+	w.log("removing %s", path)
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+	// also remove folders that became empty
+	root := filepath.Clean(w.Root)
+	for dir := filepath.Dir(path); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if list, err := ioutil.ReadDir(dir); err != nil || len(list) > 0 {
+			break
+		}
+		w.log("removing folder %s", dir)
+		if err := os.Remove(dir); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func (w *Writer) writeManifest(manifest, old map[string]string, hasManifest bool) error {
+	if hasManifest && len(manifest) == len(old) {
+		same := true
+		for k, v := range manifest {
+			if old[k] != v {
+				same = false
+				break
+			}
+		}
+		if same {
+			return nil
+		}
+	}
+	var out bytes.Buffer
+	out.WriteString(manifestHeader)
+	for _, name := range sortedKeys(manifest) {
+		fmt.Fprintf(&out, "%s  %s\n", manifest[name], name)
+	}
+	return ioutil.WriteFile(w.manifestPath(), out.Bytes(), 0666)
+}
+
+func (w *Writer) manifestPath() string {
+	return filepath.Join(w.Root, ManifestFile(w.Target))
+}
+
+func (w *Writer) log(format string, args ...interface{}) {
+	if w.Log != nil {
+		w.Log(format, args...)
+	}
+}
+
+// readManifest is reading a manifest file. A missing file is
+// returning an empty manifest.
+func readManifest(filename string) (map[string]string, bool, error) {
+	ret := make(map[string]string)
+	content, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return ret, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.Index(line, "  ")
+		if idx == -1 {
+			return nil, false, fmt.Errorf("%s:%d: invalid manifest line", filename, lineno)
+		}
+		ret[line[idx+2:]] = line[:idx]
+	}
+	return ret, true, scanner.Err()
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedKeys(m map[string]string) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,182 @@
+package output
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteUnchanged(t *testing.T) {
+	w := &Writer{Root: t.TempDir()}
+	files := []*File{
+		{Name: "a/a.go", Content: []byte("package a\n")},
+		{Name: "b/b.go", Content: []byte("package b\n")},
+	}
+	res, err := w.Write(files)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"a/a.go", "b/b.go"}, res.Written)
+	assert.FileExists(t, filepath.Join(w.Root, ManifestName))
+
+	// make a modification visible in mod time
+	old := time.Now().Add(-time.Hour)
+	path := filepath.Join(w.Root, "a", "a.go")
+	assert.Nil(t, os.Chtimes(path, old, old))
+
+	files[1].Content = []byte("package b\n\nvar B int\n")
+	res, err = w.Write(files)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"a/a.go"}, res.Unchanged)
+	assert.Equal(t, []string{"b/b.go"}, res.Written)
+	assert.Empty(t, res.Conflicts)
+	fi, err := os.Stat(path)
+	if assert.Nil(t, err) {
+		assert.True(t, fi.ModTime().Equal(old))
+	}
+}
+
+func TestWritePrune(t *testing.T) {
+	w := &Writer{Root: t.TempDir()}
+	_, err := w.Write([]*File{
+		{Name: "a/a.go", Content: []byte("package a\n")},
+		{Name: "b/b.go", Content: []byte("package b\n")},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	res, err := w.Write([]*File{
+		{Name: "a/a.go", Content: []byte("package a\n")},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"b/b.go"}, res.Removed)
+	assert.NoDirExists(t, filepath.Join(w.Root, "b"))
+
+	manifest, _, err := readManifest(filepath.Join(w.Root, ManifestName))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(manifest))
+	assert.Contains(t, manifest, "a/a.go")
+}
+
+func TestWriteConflict(t *testing.T) {
+	w := &Writer{Root: t.TempDir()}
+	files := []*File{
+		{Name: "a/a.go", Content: []byte("package a\n")},
+		{Name: "a/b.go", Content: []byte("package a\n")},
+	}
+	_, err := w.Write(files)
+	if !assert.Nil(t, err) {
+		return
+	}
+	edited := []byte("package a\n\n// my change\n")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(w.Root, "a", "a.go"), edited, 0666))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(w.Root, "a", "b.go"), edited, 0666))
+
+	files[0].Content = []byte("package a\n\nvar A int\n")
+	res, err := w.Write(files[:1])
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []*Conflict{
+		{Name: "a/a.go"},
+		{Name: "a/b.go", Stale: true},
+	}, res.Conflicts)
+	content, _ := ioutil.ReadFile(filepath.Join(w.Root, "a", "a.go"))
+	assert.Equal(t, edited, content)
+	assert.FileExists(t, filepath.Join(w.Root, "a", "b.go"))
+
+	// still a conflict in next run
+	res, err = w.Write(files[:1])
+	if assert.Nil(t, err) {
+		assert.Equal(t, 2, len(res.Conflicts))
+	}
+
+	w.Force = true
+	res, err = w.Write(files[:1])
+	if assert.Nil(t, err) {
+		assert.Empty(t, res.Conflicts)
+		assert.Equal(t, []string{"a/a.go"}, res.Written)
+		assert.Equal(t, []string{"a/b.go"}, res.Removed)
+	}
+}
+
+func TestWriteUnknownFile(t *testing.T) {
+	w := &Writer{Root: t.TempDir()}
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(w.Root, "own.go"), []byte("mine"), 0666))
+
+	// also without a manifest existing files are not overwritten
+	files := []*File{
+		{Name: "gen.go", Content: []byte("package gen\n")},
+		{Name: "own.go", Content: []byte("package gen\n")},
+	}
+	res, err := w.Write(files)
+	if assert.Nil(t, err) {
+		assert.Equal(t, []string{"gen.go"}, res.Written)
+		assert.Equal(t, []*Conflict{{Name: "own.go", Unknown: true}}, res.Conflicts)
+	}
+	res, err = w.Write(files)
+	if assert.Nil(t, err) {
+		assert.Equal(t, []*Conflict{{Name: "own.go", Unknown: true}}, res.Conflicts)
+	}
+	content, _ := ioutil.ReadFile(filepath.Join(w.Root, "own.go"))
+	assert.Equal(t, "mine", string(content))
+
+	w.Force = true
+	res, err = w.Write(files)
+	if assert.Nil(t, err) {
+		assert.Empty(t, res.Conflicts)
+		assert.Equal(t, []string{"own.go"}, res.Written)
+	}
+}
+
+func TestWriteAdoptIdentical(t *testing.T) {
+	w := &Writer{Root: t.TempDir()}
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(w.Root, "gen.go"), []byte("package gen\n"), 0666))
+
+	res, err := w.Write([]*File{{Name: "gen.go", Content: []byte("package gen\n")}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Empty(t, res.Conflicts)
+	assert.Equal(t, []string{"gen.go"}, res.Unchanged)
+
+	// adopted file is updated in next run
+	res, err = w.Write([]*File{{Name: "gen.go", Content: []byte("package gen\n\nvar A int\n")}})
+	if assert.Nil(t, err) {
+		assert.Empty(t, res.Conflicts)
+		assert.Equal(t, []string{"gen.go"}, res.Written)
+	}
+}
+
+func TestWriteTargets(t *testing.T) {
+	root := t.TempDir()
+	gowasm := &Writer{Root: root, Target: "gowasm"}
+	json := &Writer{Root: root, Target: "json"}
+	_, err := gowasm.Write([]*File{{Name: "a/a.go", Content: []byte("package a\n")}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	res, err := json.Write([]*File{{Name: "model.json", Content: []byte("{}\n")}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Empty(t, res.Removed)
+	assert.FileExists(t, filepath.Join(root, "a", "a.go"))
+	assert.FileExists(t, filepath.Join(root, ManifestFile("gowasm")))
+	assert.FileExists(t, filepath.Join(root, ManifestFile("json")))
+
+	// files are still removed by the target that wrote them
+	res, err = gowasm.Write(nil)
+	if assert.Nil(t, err) {
+		assert.Equal(t, []string{"a/a.go"}, res.Removed)
+		assert.FileExists(t, filepath.Join(root, "model.json"))
+	}
+}
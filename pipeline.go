@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/gowasm"
+	"github.com/gowebapi/webidl-bind/transform"
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// pipeline is the result of loading all input files
+type pipeline struct {
+	trans *transform.Transform
+	conv  *types.Convert
+}
+
+// loadInput is reading all WebIDL and transform files, progress
+// messages are written to log
+func loadInput(log io.Writer) (*pipeline, error) {
+	p := &pipeline{
+		trans: transform.New(),
+		conv:  types.NewConvert(),
+	}
+	p.trans.Log = log
+	setup := &types.Setup{
+		Package: args.singlePkg,
+		Error:   failing,
+		Warning: warning,
+	}
+	for _, name := range args.inputs {
+		ext := filepath.Ext(name)
+		if ext == ".md" || strings.HasSuffix(name, transform.JSONSuffix) {
+			fmt.Fprintln(log, "reading modificaton file", name)
+			pkg := gowasm.FormatPkg(name, args.singlePkg)
+			if err := p.trans.Load(name, pkg); err != nil {
+				return nil, err
+			}
+		} else if ext == ".idl" {
+			fmt.Fprintln(log, "reading WebIDL file", name)
+			if err := processFile(name, p.conv, setup); err != nil {
+				return nil, err
+			}
+		} else {
+			fmt.Fprintln(log, "skipping", name)
+		}
+	}
+	if err := p.conv.Evaluate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// loadAndTransform is loading all input files and apply transformations
+func loadAndTransform(log io.Writer) (*pipeline, error) {
+	p, err := loadInput(log)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.trans.Execute(p.conv); err != nil {
+		return nil, err
+	}
+	transform.RenameOverrideMethods(p.conv)
+	p.conv.Sort()
+	return p, nil
+}
+
+func processFile(filename string, conv *types.Convert, setup *types.Setup) error {
+	setup.Package = gowasm.FormatPkg(filename, args.singlePkg)
+	setup.Filename = filename
+	if err := conv.Load(setup); err != nil {
+		return err
+	}
+	return nil
+}
@@ -26,6 +26,9 @@ type actionData struct {
 	eventMap  map[string]struct{}
 	eventAttr []arg
 	lastGroup string
+
+	// mergeName is set when the changes are done on a mixin
+	mergeName string
 }
 
 func (ad *actionData) nextType(value types.Type) {
@@ -38,6 +41,13 @@ func (ad *actionData) nextType(value types.Type) {
 
 type notifyMsg interface {
 	messageError(ref ref, format string, args ...interface{})
+	skipped(item *SkippedItem)
+	skippedType(value types.Type) *SkippedItem
+
+	// used by lint to find rules that doesn't do anything
+	matched(rule ref)
+	applied(rule ref, changed bool)
+	renamed(target *types.Ref, name string, rule ref, value string)
 }
 
 type scopeMode int
@@ -85,8 +95,11 @@ type property struct {
 
 func (t *property) ExecuteCallback(instance *types.Callback, data *actionData) {
 	if f, ok := callbackProperties[t.Name]; ok {
+		current := f.Get(instance)
 		if msg := f.Set(instance, t.Value); msg != "" {
 			data.notify.messageError(t.Ref, msg)
+		} else {
+			t.afterSet(instance, current, data)
 		}
 	} else {
 		data.notify.messageError(t.Ref, "unknown property '%s', valid are: %s",
@@ -96,8 +109,11 @@ func (t *property) ExecuteCallback(instance *types.Callback, data *actionData) {
 
 func (t *property) ExecuteDictionary(instance *types.Dictionary, data *actionData) {
 	if f, ok := dictionaryProperties[t.Name]; ok {
+		current := f.Get(instance)
 		if msg := f.Set(instance, t.Value); msg != "" {
 			data.notify.messageError(t.Ref, msg)
+		} else {
+			t.afterSet(instance, current, data)
 		}
 	} else {
 		data.notify.messageError(t.Ref, "unknown property '%s', valid are: %s",
@@ -107,8 +123,11 @@ func (t *property) ExecuteDictionary(instance *types.Dictionary, data *actionDat
 
 func (t *property) ExecuteEnum(instance *types.Enum, data *actionData) {
 	if f, ok := enumProperties[t.Name]; ok {
+		current := f.Get(instance)
 		if msg := f.Set(instance, t.Value); msg != "" {
 			data.notify.messageError(t.Ref, msg)
+		} else {
+			t.afterSet(instance, current, data)
 		}
 	} else {
 		data.notify.messageError(t.Ref, "unknown property '%s', valid are: %s",
@@ -117,9 +136,16 @@ func (t *property) ExecuteEnum(instance *types.Enum, data *actionData) {
 }
 
 func (t *property) ExecuteInterface(instance *types.Interface, data *actionData) {
+	if t.Name == "skip" && data.mergeName != "" {
+		data.notify.messageError(t.Ref, "'%s' is a mixin, skip individual members with '@skip' instead", data.mergeName)
+		return
+	}
 	if f, ok := interfaceProperties[t.Name]; ok {
+		current := f.Get(instance)
 		if msg := f.Set(instance, t.Value); msg != "" {
 			data.notify.messageError(t.Ref, msg)
+		} else {
+			t.afterSet(instance, current, data)
 		}
 	} else {
 		data.notify.messageError(t.Ref, "unknown property '%s', valid are: %s",
@@ -138,6 +164,24 @@ func (t *property) ExecuteStatus(instance *SpecStatus, data *actionData) {
 	}
 }
 
+// afterSet is remembering what transform line changed the name
+// and if the type is skipped
+func (t *property) afterSet(on types.Type, previous string, data *actionData) {
+	data.notify.applied(t.Ref, previous != t.Value)
+	if t.Name == "name" {
+		on.SourceReference().RenamedBy = t.Ref.String()
+		data.notify.renamed(on.SourceReference(), on.Basic().Idl, t.Ref, t.Value)
+	}
+	on.SourceReference().AddTrace("property %s = %s by %s", t.Name, t.Value, t.Ref)
+	if t.Name == "skip" && !on.InUse() {
+		data.notify.skipped(&SkippedItem{
+			Name:      on.Basic().Idl,
+			Source:    on.SourceReference(),
+			Transform: t.Ref,
+		})
+	}
+}
+
 func (t property) OperateOn() scopeMode {
 	_, found := globalProperties[t.Name]
 	if found {
@@ -185,7 +229,13 @@ func (t *rename) ExecuteInterface(value *types.Interface, data *actionData) {
 
 func genericRename(name, value string, ref ref, targets map[string]renameTarget, notify notifyMsg) {
 	if target, found := targets[name]; found {
+		notify.applied(ref, target.Name().Def != value)
 		target.Name().Def = value
+		if r, ok := target.(types.GetRef); ok && r.SourceReference() != nil {
+			r.SourceReference().RenamedBy = ref.String()
+			r.SourceReference().AddTrace("renamed to %s by %s", value, ref)
+			notify.renamed(r.SourceReference(), name, ref, value)
+		}
 	} else {
 		notify.messageError(ref, "unknown rename target '%s'", name)
 	}
@@ -227,6 +277,15 @@ type changeType struct {
 	abstractAction
 	Name  string
 	RawJS string
+
+	// IdlType is a WebIDL type expression, used if RawJS is empty
+	IdlType string
+}
+
+// typeTarget is something that can change type
+type typeTarget interface {
+	GetType() types.TypeRef
+	SetType(value types.TypeRef) string
 }
 
 func (t *changeType) OperateOn() scopeMode {
@@ -234,7 +293,15 @@ func (t *changeType) OperateOn() scopeMode {
 }
 
 func (t *changeType) ExecuteCallback(instance *types.Callback, data *actionData) {
-	data.notify.messageError(t.Ref, "type change in callback in not yet implmented")
+	if t.Name == "return" {
+		t.change(&callbackReturn{instance}, data)
+		return
+	}
+	if p := types.FindParameter(instance.Parameters, t.Name); p != nil {
+		t.change(p, data)
+		return
+	}
+	data.notify.messageError(t.Ref, "unknown parameter '%s', valid are 'return' or a parameter name", t.Name)
 }
 
 func (t *changeType) ExecuteDictionary(value *types.Dictionary, data *actionData) {
@@ -243,11 +310,7 @@ func (t *changeType) ExecuteDictionary(value *types.Dictionary, data *actionData
 		data.notify.messageError(t.Ref, "unknown reference")
 		return
 	}
-	idl := on.GetType().Basic().Idl
-	raw := types.NewRawJSType(idl)
-	if msg := on.SetType(raw); msg != "" {
-		data.notify.messageError(t.Ref, "type change error: %s", msg)
-	}
+	t.change(on, data)
 }
 
 func (t *changeType) ExecuteEnum(value *types.Enum, data *actionData) {
@@ -255,18 +318,116 @@ func (t *changeType) ExecuteEnum(value *types.Enum, data *actionData) {
 }
 
 func (t *changeType) ExecuteInterface(value *types.Interface, data *actionData) {
-	on, found := data.targets[t.Name]
+	// method.param is changing an operation parameter and
+	// method.return the return type
+	name, param := t.Name, ""
+	if idx := strings.Index(name, "."); idx != -1 {
+		name, param = name[:idx], name[idx+1:]
+	}
+	on, found := data.targets[name]
 	if !found {
 		data.notify.messageError(t.Ref, "unknown reference")
 		return
 	}
-	idl := on.GetType().Basic().Idl
-	raw := types.NewRawJSType(idl)
-	if msg := on.SetType(raw); msg != "" {
+	if param == "" {
+		t.change(on, data)
+		return
+	}
+	method, ok := on.(*types.IfMethod)
+	if !ok {
+		data.notify.messageError(t.Ref, "'%s' is not a method with parameters", name)
+		return
+	}
+	if param == "return" {
+		t.change(&methodReturn{method}, data)
+		return
+	}
+	if p := types.FindParameter(method.Params, param); p != nil {
+		t.change(p, data)
+		return
+	}
+	data.notify.messageError(t.Ref, "method '%s' doesn't have any parameter '%s'", name, param)
+}
+
+// change is replacing the type with rawjs or the WebIDL type
+func (t *changeType) change(on typeTarget, data *actionData) {
+	var value types.TypeRef
+	desc := t.IdlType
+	if t.IdlType == "" {
+		value = types.NewRawJSType(on.GetType().Basic().Idl)
+		desc = "rawjs"
+	} else {
+		// parsing is marking all used types as in use
+		unused := make(map[types.Type]bool)
+		for _, typ := range data.conv.All {
+			if !typ.InUse() {
+				unused[typ] = true
+			}
+		}
+		ref := &types.Ref{Filename: t.Ref.Filename, Line: t.Ref.Line}
+		parsed, err := data.conv.ParseType(t.IdlType, ref)
+		if err != nil {
+			data.notify.messageError(t.Ref, "type change error: %s", err)
+			return
+		}
+		var skipped *SkippedItem
+		typeReferences(parsed, func(used types.Type) {
+			if skipped == nil {
+				skipped = data.notify.skippedType(used)
+			}
+		})
+		if skipped != nil {
+			for typ := range unused {
+				typ.SetInUse(false)
+			}
+			data.notify.messageError(t.Ref, "type change error: type %s is skipped at %s", skipped.Name, skipped.Transform)
+			return
+		}
+		value = parsed
+	}
+	if msg := on.SetType(value); msg != "" {
 		data.notify.messageError(t.Ref, "type change error: %s", msg)
+	} else if r, ok := on.(types.GetRef); ok {
+		r.SourceReference().AddTrace("type changed to %s by %s", desc, t.Ref)
 	}
 }
 
+// callbackReturn is making it possible to change callback return type
+type callbackReturn struct {
+	cb *types.Callback
+}
+
+func (t *callbackReturn) GetType() types.TypeRef {
+	return t.cb.Return
+}
+
+func (t *callbackReturn) SetType(value types.TypeRef) string {
+	t.cb.Return = value
+	return ""
+}
+
+func (t *callbackReturn) SourceReference() *types.Ref {
+	return t.cb.SourceReference()
+}
+
+// methodReturn is making it possible to change method return type
+type methodReturn struct {
+	method *types.IfMethod
+}
+
+func (t *methodReturn) GetType() types.TypeRef {
+	return t.method.Return
+}
+
+func (t *methodReturn) SetType(value types.TypeRef) string {
+	t.method.SetReturn(value)
+	return ""
+}
+
+func (t *methodReturn) SourceReference() *types.Ref {
+	return t.method.SourceReference()
+}
+
 type idlconst struct {
 	abstractAction
 }
@@ -293,6 +454,7 @@ func (t *idlconst) ExecuteInterface(value *types.Interface, data *actionData) {
 		idl := m.Idl
 		m.Def = strings.ToUpper(idl[:1]) + idl[1:]
 		c.SetName(m)
+		c.SourceReference().AddTrace("renamed to %s by idlconst at %s", m.Def, t.Ref)
 	}
 }
 
@@ -391,6 +553,7 @@ func (ce *commonEventData) addEventToInterface(inf *types.Interface, ev *types.I
 	ev.ShortName = ce.Method
 	ev.EventName = ce.EventName
 	ev.Type = typ
+	ev.SourceReference().AddTrace("event %s of type %s added by %s", ce.EventName, ce.EventType, ce.Ref)
 	inf.Events = append(inf.Events, ev)
 }
 
@@ -448,6 +611,7 @@ func (t *event) eventFuncModify(inf *types.Interface, attr *types.IfVar, data *a
 	// modify current
 	attr.Name().Def = "On" + t.Method
 	attr.Readonly = true
+	attr.SourceReference().AddTrace("renamed to %s and made readonly by event at %s", attr.Name().Def, t.Ref)
 }
 
 type addevent struct {
@@ -508,3 +672,38 @@ func (t *setEventProp) OperateOn() scopeMode {
 func (t *setEventProp) ExecuteInterface(instance *types.Interface, data *actionData) {
 	data.eventAttr = append(data.eventAttr, t.Args...)
 }
+
+// injectCode is a go code block that is appended to the output of
+// a type
+type injectCode struct {
+	abstractAction
+	Code string
+}
+
+func (t *injectCode) OperateOn() scopeMode {
+	return scopeType
+}
+
+func (t *injectCode) ExecuteCallback(instance *types.Callback, data *actionData) {
+	t.inject(instance)
+}
+
+func (t *injectCode) ExecuteDictionary(instance *types.Dictionary, data *actionData) {
+	t.inject(instance)
+}
+
+func (t *injectCode) ExecuteEnum(instance *types.Enum, data *actionData) {
+	t.inject(instance)
+}
+
+func (t *injectCode) ExecuteInterface(instance *types.Interface, data *actionData) {
+	t.inject(instance)
+}
+
+func (t *injectCode) inject(value types.Type) {
+	value.AddInjectedCode(&types.InjectedCode{
+		Ref:  &types.Ref{Filename: t.Ref.Filename, Line: t.Ref.Line},
+		Code: t.Code,
+	})
+	value.SourceReference().AddTrace("go code added by %s", t.Ref)
+}
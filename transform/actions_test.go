@@ -0,0 +1,342 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/gowebapi/webidl-bind/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const changeTypeIdl = `
+callback Handler = void (long x, any data);
+interface Blob { };
+interface Canvas {
+  attribute long width;
+  void draw(long a, any arg2);
+  any value();
+  void listen(Handler h);
+};
+dictionary Opts {
+  any size;
+};
+callback PromiseCallback = void(any value);
+interface Promise { };
+`
+
+func TestChangeType(t *testing.T) {
+	md := `# ct
+
+.title = internal
+
+## Canvas
+
+@changetype width unsigned long
+@changetype draw.arg2 (DOMString or Blob)
+@changetype value.return DOMString?
+
+## Handler
+
+@changetype data Blob
+@changetype return boolean
+
+## Opts
+
+@changetype size rawjs
+`
+	conv, _, err := executeTransform(changeTypeIdl, md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	canvas := conv.Types["Canvas"].(*types.Interface)
+	assert.Equal(t, "unsigned long", canvas.Vars[0].Type.Basic().Idl)
+	assert.IsType(t, &types.UnionType{}, canvas.Method[0].Params[1].Type)
+	assert.Equal(t, "long", canvas.Method[0].Params[0].Type.Basic().Idl)
+	info, _ := canvas.Method[1].Return.DefaultParam()
+	assert.True(t, info.Nullable)
+	assert.Equal(t, "DOMString", canvas.Method[1].Return.Basic().Idl)
+
+	handler := conv.Types["Handler"].(*types.Callback)
+	assert.Equal(t, "boolean", handler.Return.Basic().Idl)
+	assert.Equal(t, "Blob", handler.Parameters[1].Type.Basic().Idl)
+
+	opts := conv.Types["Opts"].(*types.Dictionary)
+	assert.IsType(t, &types.RawJSType{}, opts.Members[0].Type)
+}
+
+func TestChangeTypeErrors(t *testing.T) {
+	for _, line := range []string{
+		"@changetype width Unknown",
+		"@changetype width (long or",
+		"@changetype draw.missing long",
+		"@changetype width.x long",
+		"@changetype value DOMString",
+		"@changetype width.return long",
+	} {
+		md := "# ct\n\n.title = internal\n\n## Canvas\n\n" + line + "\n"
+		_, _, err := executeTransform(changeTypeIdl, md, t)
+		assert.NotNil(t, err, line)
+	}
+}
+
+func executeTransform(idl, md string, t *testing.T) (*types.Convert, *Transform, error) {
+	return executeTransformText(idl, "ct.md", md, t)
+}
+
+// executeTransformFile is using changeTypeIdl with a transform file
+// that can include other files
+func executeTransformFile(filename, md string, t *testing.T) (*types.Convert, *Transform, error) {
+	return executeTransformText(changeTypeIdl, filename, md, t)
+}
+
+func executeTransformText(idl, filename, md string, t *testing.T) (*types.Convert, *Transform, error) {
+	conv := types.NewConvert()
+	setup := &types.Setup{
+		Filename: "ct.idl",
+		Package:  "ct",
+		Error: func(ref types.GetRef, format string, args ...interface{}) {
+			t.Errorf(format, args...)
+		},
+		Warning: func(ref types.GetRef, format string, args ...interface{}) {},
+	}
+	if err := conv.Parse([]byte(idl), setup); err != nil {
+		return nil, nil, err
+	}
+	if err := conv.Evaluate(); err != nil {
+		return nil, nil, err
+	}
+	trans := New()
+	if err := parseText(filename, md, "ct", trans); err != nil {
+		return nil, nil, err
+	}
+	return conv, trans, trans.Execute(conv)
+}
+
+func TestSkip(t *testing.T) {
+	md := `# ct
+
+.title = internal
+
+@on interface "^Bl": .skip = true
+
+## Canvas
+
+@skip draw
+@skip width
+
+## Handler
+
+.skip = true
+
+## Opts
+
+@skip size
+`
+	conv, trans, err := executeTransform(changeTypeIdl, md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	canvas := conv.Types["Canvas"].(*types.Interface)
+	assert.Len(t, canvas.Vars, 0)
+	assert.Len(t, canvas.Method, 2)
+	assert.Len(t, conv.Types["Opts"].(*types.Dictionary).Members, 0)
+	assert.False(t, conv.Types["Handler"].InUse())
+	assert.False(t, conv.Types["Blob"].InUse())
+	assert.True(t, canvas.InUse())
+
+	names := []string{}
+	for _, item := range trans.Skipped {
+		names = append(names, item.Name+"@"+item.Transform.String())
+	}
+	assert.Equal(t, []string{"Blob@ct.md:5", "Canvas.draw@ct.md:9", "Canvas.width@ct.md:10",
+		"Handler@ct.md:14", "Opts.size@ct.md:18"}, names)
+}
+
+func TestSkipErrors(t *testing.T) {
+	for _, section := range []string{
+		"## Canvas\n\n@skip missing\n",
+		"## Handler\n\n@skip x\n",
+		"## Canvas\n\n.skip = maybe\n",
+	} {
+		md := "# ct\n\n.title = internal\n\n" + section
+		_, _, err := executeTransform(changeTypeIdl, md, t)
+		assert.NotNil(t, err, section)
+	}
+}
+
+func TestChangeTypeToSkipped(t *testing.T) {
+	md := "# ct\n\n.title = internal\n\n## Blob\n\n.skip = true\n\n## Canvas\n\n@changetype value Blob\n"
+	conv, _, err := executeTransform(changeTypeIdl, md, t)
+	assert.NotNil(t, err)
+	assert.False(t, conv.Types["Blob"].InUse())
+}
+
+func TestInjectCode(t *testing.T) {
+	md := "# ct\n\n.title = internal\n\n## Canvas\n\n" +
+		"    ```go\n    import \"strings\"\n\n    func (_this *Canvas) Name() string {\n" +
+		"    \treturn strings.ToLower(\"x\")\n    }\n    ```\n\n" +
+		"## Opts\n\n```go\nconst OptsSize = 4\n```\n"
+	conv, _, err := executeTransform(changeTypeIdl, md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	list := conv.Types["Canvas"].InjectedCode()
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "ct.md:7", list[0].Ref.String())
+		assert.Equal(t, "import \"strings\"\n\nfunc (_this *Canvas) Name() string {\n"+
+			"\treturn strings.ToLower(\"x\")\n}\n", list[0].Code)
+	}
+	list = conv.Types["Opts"].InjectedCode()
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "ct.md:17", list[0].Ref.String())
+		assert.Equal(t, "const OptsSize = 4\n", list[0].Code)
+	}
+}
+
+func TestInjectCodeErrors(t *testing.T) {
+	for _, md := range []string{
+		"# ct\n\n.title = internal\n\n## Canvas\n\n```go\nfunc x() {}\n",
+		"# ct\n\n.title = internal\n\n## Canvas\n\n```go extra\nfunc x() {}\n```\n",
+		"# ct\n\n.title = internal\n\n```go\nfunc x() {}\n```\n",
+	} {
+		_, _, err := executeTransform(changeTypeIdl, md, t)
+		assert.NotNil(t, err, md)
+	}
+}
+
+func TestPatternHeader(t *testing.T) {
+	md := `# ct
+
+.title = internal
+
+## /^(Blob|Canvas)$/
+
+.package = shared
+
+## Can*
+
+width = size
+draw = Paint
+
+## Canvas
+
+width = canvasWidth
+`
+	conv, trans, err := executeTransform(changeTypeIdl, md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, trans.Patterns, 2)
+	assert.Equal(t, "shared", conv.Types["Blob"].Basic().Package)
+	assert.Equal(t, "ct", conv.Types["Opts"].Basic().Package)
+	canvas := conv.Types["Canvas"].(*types.Interface)
+	assert.Equal(t, "shared", canvas.Basic().Package)
+	assert.Equal(t, "canvasWidth", canvas.Vars[0].Name().Def)
+	assert.Equal(t, "Paint", canvas.Method[0].Name().Def)
+}
+
+func TestPatternHeaderErrors(t *testing.T) {
+	for _, section := range []string{
+		"## Nothing*\n\n.package = x\n",
+		"## Ca*\n\nwidth = a\n\n## *nvas\n\nwidth = b\n",
+		"## /(/\n\n.package = x\n",
+		"## /abc\n",
+		"## Ca*\n\n## Ca*\n",
+	} {
+		md := "# ct\n\n.title = internal\n\n" + section
+		_, _, err := executeTransform(changeTypeIdl, md, t)
+		assert.NotNil(t, err, section)
+	}
+}
+
+func TestMemberRule(t *testing.T) {
+	idl := changeTypeIdl + `
+interface Store {
+  Promise<any> getItem(DOMString key);
+  Promise<any> getAll();
+  long getCount();
+  [SameObject] readonly attribute Blob data;
+  attribute long size;
+  const long MAX_SIZE = 1;
+  static void reset(Blob b);
+  void put(Blob b);
+};
+`
+	md := `# ct
+
+.title = internal
+
+@on operation returns "^Promise$" "^get(.+)$": .name = ${1}Async
+@on attribute extattr "^SameObject$" ".": @skip
+@on member static "^reset$": .name = Clear
+@on operation param "^Blob$" "^p": .name = PutBlob
+@on constant type "^Store$" "MAX": .name = Max
+`
+	conv, trans, err := executeTransformText(idl, "ct.md", md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	store := conv.Types["Store"].(*types.Interface)
+	names := []string{}
+	for _, m := range append(store.Method, store.StaticMethod...) {
+		names = append(names, m.Name().Def)
+	}
+	assert.Equal(t, []string{"ItemAsync", "AllAsync", "GetCount", "PutBlob", "Clear"}, names)
+	if assert.Len(t, store.Vars, 1) {
+		assert.Equal(t, "size", store.Vars[0].Name().Idl)
+	}
+	assert.Equal(t, "Max", store.Consts[0].Name().Def)
+	assert.Equal(t, "ct.md:5", store.Method[0].SourceReference().RenamedBy)
+	if assert.Len(t, trans.Skipped, 1) {
+		assert.Equal(t, "Store.data", trans.Skipped[0].Name)
+	}
+}
+
+func TestMemberRuleErrors(t *testing.T) {
+	for _, line := range []string{
+		"@on attribute \"x\": .package = foo",
+		"@on attribute param \"x\" \"y\": .name = z",
+		"@on operation returns \"(\" \"y\": .name = z",
+		"@on operation unknown \"y\": .name = z",
+	} {
+		md := "# ct\n\n.title = internal\n\n" + line + "\n"
+		_, _, err := executeTransform(changeTypeIdl, md, t)
+		assert.NotNil(t, err, line)
+	}
+}
+
+func TestLint(t *testing.T) {
+	idl := changeTypeIdl + `
+interface Store {
+  long getCount();
+  void put(Blob b);
+};
+`
+	md := `# ct
+
+.title = internal
+
+@on interface "^NoSuchType": .namespace = foo
+@on operation "^nothing$": .name = foo
+@on operation "^put$": .name = Write
+
+## Store
+
+.name = Store
+getCount = Count
+put = Put
+`
+	_, trans, err := executeTransformText(idl, "ct.md", md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	found := []string{}
+	for _, msg := range trans.Lint() {
+		found = append(found, msg.String())
+	}
+	assert.Equal(t, []string{
+		"ct.md:5: regexp rule '^NoSuchType' doesn't match any type",
+		"ct.md:6: member rule '^nothing$' doesn't match any member",
+		"ct.md:11: '.name' is set to the value it already has",
+		"ct.md:13: 'put' renamed to 'Put' is also renamed to 'Write' by ct.md:7",
+	}, found)
+}
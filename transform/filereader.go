@@ -1,9 +1,16 @@
 package transform
 
 import (
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 )
 
+// JSONSuffix is the filename suffix of a transform file in json
+// format, other json files are not transform files
+const JSONSuffix = ".transform.json"
+
 // type parser struct {
 // 	result      *Transform
 // 	ref         ref
@@ -22,10 +29,16 @@ const (
 	matchDictionary
 )
 
+// Load is reading a transform file, in markdown or json format
 func (t *Transform) Load(filename, packageName string) error {
 	all, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return err
 	}
+	if strings.HasSuffix(filename, JSONSuffix) {
+		return parseJSON(filename, all, packageName, t)
+	} else if filepath.Ext(filename) == ".json" {
+		return fmt.Errorf("%s: a json transform file must end with %s", filename, JSONSuffix)
+	}
 	return parseText(filename, string(all), packageName, t)
 }
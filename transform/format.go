@@ -0,0 +1,232 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fmtSection is a file or type section in a transform file
+// that is being formatted
+type fmtSection struct {
+	// header is the type header line, empty for the file section
+	header  string
+	name    string
+	pattern bool
+
+	// barrier is a section with @define or @include, it's not moved
+	// as later sections can depend on it
+	barrier bool
+
+	// comments is directly before the type header
+	comments []string
+	lines    []string
+}
+
+// Format is returning a transform file in canonical form. Type
+// sections are sorted with wildcard and regular expression headers
+// first, white spaces are normalised and values are only quoted
+// when needed. Comments and go code blocks are kept.
+func Format(filename string, content []byte) ([]byte, error) {
+	text := strings.Replace(string(content), "\r\n", "\n", -1)
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	file := &fmtSection{}
+	sections := []*fmtSection{file}
+	current := file
+	define := false
+	for idx := 0; idx < len(lines); idx++ {
+		line := strings.TrimRight(lines[idx], " \t\r")
+		at := fmt.Sprintf("%s:%d", filename, idx+1)
+		if isFmtCodeFence(line) {
+			end := idx + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != codeFence {
+				end++
+			}
+			if end == len(lines) {
+				return nil, fmt.Errorf("%s: missing end of code block, expected '%s'", at, codeFence)
+			}
+			// code is kept as it is
+			current.lines = append(current.lines, strings.Join(lines[idx:end+1], "\n"))
+			idx = end
+			continue
+		}
+		switch {
+		case line == "":
+			current.lines = append(current.lines, "")
+			continue
+		case isWhitespace(rune(line[0])):
+			current.lines = append(current.lines, formatComment(line))
+			continue
+		}
+		formatted, items, err := formatLine(line)
+		if err != nil {
+			if define {
+				// parameters can make a line invalid until applied
+				current.lines = append(current.lines, line)
+				continue
+			}
+			return nil, fmt.Errorf("%s: %s", at, err)
+		}
+		if items[0].typ == itemCommand {
+			switch items[0].val {
+			case "define":
+				define = true
+				current.barrier = true
+			case "end":
+				define = false
+			case "include":
+				current.barrier = true
+			}
+		}
+		if define || items[0].typ != itemTypeHeader {
+			current.lines = append(current.lines, formatted)
+			continue
+		}
+		if len(items) < 2 {
+			return nil, fmt.Errorf("%s: missing type name after ##", at)
+		}
+		current = &fmtSection{
+			header:   formatted,
+			name:     items[1].val,
+			pattern:  items[1].typ == itemPattern,
+			comments: takeTrailingComments(current),
+		}
+		sections = append(sections, current)
+	}
+	types := sections[1:]
+	start := 0
+	for i := 0; i <= len(types); i++ {
+		if i == len(types) || types[i].barrier {
+			sortSections(types[start:i])
+			start = i + 1
+		}
+	}
+
+	out := trimBlankLines(file.lines)
+	for _, s := range types {
+		if len(out) > 0 {
+			out = append(out, "")
+		}
+		out = append(out, s.comments...)
+		out = append(out, s.header)
+		if body := trimBlankLines(s.lines); len(body) > 0 {
+			out = append(out, "")
+			out = append(out, body...)
+		}
+	}
+	return []byte(strings.Join(out, "\n") + "\n"), nil
+}
+
+// sortSections is placing wildcard and regular expression headers
+// first as they are executed first, then by type name
+func sortSections(list []*fmtSection) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].pattern != list[j].pattern {
+			return list[i].pattern
+		}
+		return list[i].name < list[j].name
+	})
+}
+
+func isFmtCodeFence(line string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line, " \t"), codeFence+"go")
+}
+
+// formatLine is using the lexer to get all parts of a line and
+// write them back with single spaces in between
+func formatLine(line string) (string, []item, error) {
+	l := newLex("", line+"\n")
+	items := []item{}
+	for {
+		it := l.nextItem()
+		if it.typ == itemError {
+			return "", nil, fmt.Errorf("%s", it.val)
+		}
+		if it.typ == itemNewLine || it.typ == itemEOF {
+			break
+		}
+		items = append(items, it)
+	}
+	if len(items) == 0 {
+		return "", nil, fmt.Errorf("unable to make sence of line")
+	}
+	// event arguments is written as name:value
+	event := items[0].typ == itemCommand && strings.Contains(items[0].val, "event")
+	var out strings.Builder
+	for i, it := range items {
+		text := it.val
+		switch it.typ {
+		case itemCommand:
+			text = "@" + text
+		case itemValue:
+			text = strings.TrimSpace(text)
+		case itemString:
+			text = `"` + text + `"`
+			if i > 0 && isSpecial(items[i-1], "=") && canBeValue(it.val) {
+				text = it.val
+			}
+		}
+		if i > 0 && !isSpecial(it, ":") && !isSpecial(it, ",") && !isSpecial(items[i-1], ".") &&
+			!(event && isSpecial(items[i-1], ":")) && !isSpecial(it, "(") && !isSpecial(it, ")") &&
+			!isSpecial(items[i-1], "(") {
+			out.WriteString(" ")
+		}
+		out.WriteString(text)
+	}
+	return out.String(), items, nil
+}
+
+func isSpecial(it item, value string) bool {
+	return it.typ == itemSpecial && it.val == value
+}
+
+// canBeValue is true if a string doesn't need to be inside "..."
+func canBeValue(text string) bool {
+	return text != "" && strings.TrimSpace(text) == text && !strings.HasPrefix(text, `"`)
+}
+
+// formatComment is indenting a comment line with at least four spaces
+func formatComment(line string) string {
+	width := 0
+	for _, ch := range line {
+		if ch == ' ' {
+			width++
+		} else if ch == '\t' {
+			width += 4 - width%4
+		} else {
+			break
+		}
+	}
+	if width < 4 {
+		width = 4
+	}
+	return strings.Repeat(" ", width) + strings.TrimLeft(line, " \t")
+}
+
+// takeTrailingComments is removing comment lines at the end of a
+// section, they belong to next type header
+func takeTrailingComments(s *fmtSection) []string {
+	idx := len(s.lines)
+	for idx > 0 && strings.HasPrefix(s.lines[idx-1], " ") && !strings.Contains(s.lines[idx-1], "\n") {
+		idx--
+	}
+	ret := append([]string{}, s.lines[idx:]...)
+	s.lines = s.lines[:idx]
+	return ret
+}
+
+// trimBlankLines is removing blank lines at start and end and
+// repeated blank lines in between
+func trimBlankLines(lines []string) []string {
+	ret := []string{}
+	for _, line := range lines {
+		if line == "" && (len(ret) == 0 || ret[len(ret)-1] == "") {
+			continue
+		}
+		ret = append(ret, line)
+	}
+	if len(ret) > 0 && ret[len(ret)-1] == "" {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
@@ -0,0 +1,90 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	input := "#   ct\n\n\n" +
+		".title   =   internal\n" +
+		"@on  interface   \"^Web\" :  .package=webgl\n" +
+		"@define names( prefix,other )\n" +
+		"width = ${prefix}Width\n" +
+		"@end\n" +
+		"\n" +
+		"## Opts\n" +
+		"  size=\"Size\"\n" +
+		"size = \"Size\"\n" +
+		".name = \" spaced\"\n" +
+		"\n\n" +
+		"\tcomment about Canvas\n" +
+		"## Canvas\n" +
+		"@apply names(canvas,x)\n" +
+		"@event   Click  MouseEvent bubbles : true ,cancelable:false\n" +
+		"@replace .name   \"a\"  \"b\"\n" +
+		"   ```go\n" +
+		"   func (_this *Canvas) Foo() {\n" +
+		"   \tbar()\n" +
+		"   }\n" +
+		"   ```\n" +
+		"## HTML*Element\n" +
+		".package = html\n"
+	expected := "# ct\n\n" +
+		".title = internal\n" +
+		"@on interface \"^Web\": .package = webgl\n" +
+		"@define names(prefix, other)\n" +
+		"width = ${prefix}Width\n" +
+		"@end\n" +
+		"\n" +
+		"## HTML*Element\n" +
+		"\n" +
+		".package = html\n" +
+		"\n" +
+		"    comment about Canvas\n" +
+		"## Canvas\n" +
+		"\n" +
+		"@apply names(canvas, x)\n" +
+		"@event Click MouseEvent bubbles:true, cancelable:false\n" +
+		"@replace .name \"a\" \"b\"\n" +
+		"   ```go\n" +
+		"   func (_this *Canvas) Foo() {\n" +
+		"   \tbar()\n" +
+		"   }\n" +
+		"   ```\n" +
+		"\n" +
+		"## Opts\n" +
+		"\n" +
+		"    size=\"Size\"\n" +
+		"size = Size\n" +
+		".name = \" spaced\"\n"
+	out, err := Format("ct.md", []byte(input))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, expected, string(out))
+
+	again, err := Format("ct.md", out)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(again))
+}
+
+func TestFormatBarrier(t *testing.T) {
+	input := "# ct\n\n## B\n\n@define x()\n.name = X\n@end\n\n## A\n\n@apply x()\n"
+	out, err := Format("ct.md", []byte(input))
+	assert.Nil(t, err)
+	assert.Equal(t, input, string(out))
+}
+
+func TestFormatErrors(t *testing.T) {
+	for _, input := range []string{
+		"# ct\n\n## Foo\n\n!foo\n",
+		"# ct\n\n## Foo\n\n```go\nfunc x() {}\n",
+		"# ct\n\n@apply 12(x)\n",
+		"# ct\n\n.name = \"foo\n",
+	} {
+		_, err := Format("ct.md", []byte(input))
+		assert.NotNil(t, err, input)
+	}
+}
@@ -65,6 +65,7 @@ type callbackProperty interface {
 var callbackProperties = map[string]callbackProperty{
 	"name":    &callbackName{},
 	"package": &callbackPackage{},
+	"skip":    &callbackSkip{},
 }
 var callbackPropertyNames = []string{}
 
@@ -81,6 +82,16 @@ func (t *callbackName) Set(cb *types.Callback, value string) string {
 	return ""
 }
 
+type callbackSkip struct{ typeSkip }
+
+func (t *callbackSkip) Get(cb *types.Callback) string {
+	return t.get(cb)
+}
+
+func (t *callbackSkip) Set(cb *types.Callback, value string) string {
+	return t.set(cb, value)
+}
+
 type callbackPackage struct{}
 
 func (t *callbackPackage) Get(cb *types.Callback) string {
@@ -103,6 +114,7 @@ type dictionaryProperty interface {
 var dictionaryProperties = map[string]dictionaryProperty{
 	"name":    &dictionaryName{},
 	"package": &dictionaryPackage{},
+	"skip":    &dictionarySkip{},
 }
 var dictionaryPropertyNames = []string{}
 
@@ -119,6 +131,16 @@ func (t *dictionaryName) Set(cb *types.Dictionary, value string) string {
 	return ""
 }
 
+type dictionarySkip struct{ typeSkip }
+
+func (t *dictionarySkip) Get(cb *types.Dictionary) string {
+	return t.get(cb)
+}
+
+func (t *dictionarySkip) Set(cb *types.Dictionary, value string) string {
+	return t.set(cb, value)
+}
+
 type dictionaryPackage struct{}
 
 func (t *dictionaryPackage) Get(cb *types.Dictionary) string {
@@ -142,6 +164,7 @@ var enumProperties = map[string]enumProperty{
 	"name":    &enumName{},
 	"package": &enumPackage{},
 	"prefix":  &enumPrefix{},
+	"skip":    &enumSkip{},
 	"suffix":  &enumSuffix{},
 }
 var enumPropertyNames = []string{}
@@ -173,6 +196,16 @@ func (t *enumPackage) Set(cb *types.Enum, value string) string {
 	return msg
 }
 
+type enumSkip struct{ typeSkip }
+
+func (t *enumSkip) Get(cb *types.Enum) string {
+	return t.get(cb)
+}
+
+func (t *enumSkip) Set(cb *types.Enum, value string) string {
+	return t.set(cb, value)
+}
+
 type enumPrefix struct{}
 
 func (t *enumPrefix) Get(cb *types.Enum) string {
@@ -206,6 +239,7 @@ var interfaceProperties = map[string]interfaceProperty{
 	"constructorName": &interfaceConstructorName{},
 	"name":            &interfaceName{},
 	"package":         &interfacePackage{},
+	"skip":            &interfaceSkip{},
 }
 var interfacePropertyNames = []string{}
 var interfaceSpecPropertyMap = map[types.SpecializationType]string{
@@ -266,6 +300,16 @@ func (t *interfaceName) Set(inf *types.Interface, value string) string {
 	return ""
 }
 
+type interfaceSkip struct{ typeSkip }
+
+func (t *interfaceSkip) Get(inf *types.Interface) string {
+	return t.get(inf)
+}
+
+func (t *interfaceSkip) Set(inf *types.Interface, value string) string {
+	return t.set(inf, value)
+}
+
 type interfacePackage struct{}
 
 func (t *interfacePackage) Get(inf *types.Interface) string {
@@ -0,0 +1,173 @@
+package transform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ruleGroup is a named and parameterised group of lines that can be
+// applied to several types
+type ruleGroup struct {
+	Name   string
+	Params []string
+	Body   string
+
+	// Ref is the @define line, the body starts on next line
+	Ref ref
+}
+
+// lexInput is a lexer reading a transform file or an applied rule
+// group. offset is added to line numbers from the lexer
+type lexInput struct {
+	lex    *lexer
+	file   string
+	offset int
+
+	// group is the applied rule group, nil for a file
+	group *ruleGroup
+}
+
+// max number of nested @apply
+const maxGroupDepth = 16
+
+var groupParamRegexp = regexp.MustCompile(`\$(\{[A-Za-z_][A-Za-z0-9_]*\}|[A-Za-z_][A-Za-z0-9_]*)`)
+
+func (lw *lexWrap) includeFile(filename string) {
+	if lw.toJSON {
+		lw.commandError("@include can't be converted, json format doesn't support it")
+		return
+	}
+	name := strings.TrimSpace(filename)
+	if name == "" {
+		lw.commandError("missing filename after @include")
+		return
+	}
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(filepath.Dir(lw.commandInput.file), name)
+	}
+	chain := []string{}
+	for _, in := range lw.stack {
+		if in.group == nil {
+			chain = append(chain, in.file)
+		}
+	}
+	for _, file := range chain {
+		if file == name {
+			lw.commandError("include cycle: %s", strings.Join(append(chain, name), " -> "))
+			return
+		}
+	}
+	content, err := ioutil.ReadFile(name)
+	if err != nil {
+		lw.commandError("unable to include file: %s", err)
+		return
+	}
+	lw.pending = &lexInput{
+		lex:  newLex(name, string(content)),
+		file: name,
+	}
+}
+
+func (lw *lexWrap) defineGroup(name string, params []string, body string) {
+	if lw.toJSON {
+		lw.commandError("@define can't be converted, json format doesn't support it")
+		return
+	}
+	if other, found := lw.groups[name]; found {
+		lw.commandError("rule group '%s' is already defined at %s", name, other.Ref)
+		return
+	}
+	for _, param := range params {
+		if !isIdentifier(param) {
+			lw.commandError("invalid parameter name '%s'", param)
+			return
+		}
+	}
+	lw.groups[name] = &ruleGroup{
+		Name:   name,
+		Params: params,
+		Body:   body,
+		Ref:    lw.lineRef(lw.commandInput, lw.command.line),
+	}
+}
+
+func (lw *lexWrap) applyGroup(name string, args []string) {
+	if lw.toJSON {
+		lw.commandError("@apply can't be converted, json format doesn't support it")
+		return
+	}
+	g, found := lw.groups[name]
+	if !found {
+		lw.commandError("unknown rule group '%s'", name)
+		return
+	}
+	if len(args) != len(g.Params) {
+		lw.commandError("rule group '%s' expects %d argument(s), got %d", name, len(g.Params), len(args))
+		return
+	}
+	depth := 0
+	for _, in := range lw.stack {
+		if in.group != nil {
+			depth++
+		}
+	}
+	if depth >= maxGroupDepth {
+		lw.commandError("too many nested @apply, recursive rule group '%s'?", name)
+		return
+	}
+	values := make(map[string]string)
+	for i, param := range g.Params {
+		values[param] = args[i]
+	}
+	lines := strings.Split(g.Body, "\n")
+	for idx, line := range lines {
+		var unknown string
+		lines[idx] = groupParamRegexp.ReplaceAllStringFunc(line, func(in string) string {
+			key := strings.Trim(in[1:], "{}")
+			if value, found := values[key]; found {
+				return value
+			}
+			if unknown == "" {
+				unknown = in
+			}
+			return in
+		})
+		if unknown != "" {
+			lw.syntax = append(lw.syntax, &SyntaxError{
+				Filename: g.Ref.Filename,
+				Line:     g.Ref.Line + idx + 1,
+				Column:   utf8.RuneCountInString(line[:strings.Index(line, unknown)]) + 1,
+				Message:  fmt.Sprintf("unknown parameter '%s' in rule group '%s'", unknown, name),
+				Source:   line,
+			})
+			return
+		}
+	}
+	lw.pending = &lexInput{
+		lex:    newLex(g.Ref.Filename, strings.Join(lines, "\n")),
+		file:   g.Ref.Filename,
+		offset: g.Ref.Line,
+		group:  g,
+	}
+}
+
+// commandError is a syntax error on last @include, @define or @apply
+func (lw *lexWrap) commandError(format string, args ...interface{}) {
+	at := lw.command
+	// pointing to '@'
+	at.pos--
+	lw.syntaxError(lw.commandInput, at, fmt.Sprintf(format, args...), nil)
+}
+
+func isIdentifier(in string) bool {
+	for i, ch := range in {
+		if !isIdentFirst(ch) && (i == 0 || !isDigit(ch)) {
+			return false
+		}
+	}
+	return in != ""
+}
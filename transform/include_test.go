@@ -0,0 +1,99 @@
+package transform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/gowebapi/webidl-bind/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludeAndApply(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "common.md"), `  shared rules
+
+@define names(prefix)
+width = ${prefix}Width
+draw = $prefix
+@end
+
+## Opts
+
+@skip size
+`)
+	main := filepath.Join(dir, "ct.md")
+	md := "# ct\n\n.title = internal\n\n@include common.md\n\n## Canvas\n\n@apply names(canvas)\n"
+	conv, _, err := executeTransformFile(main, md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	canvas := conv.Types["Canvas"].(*types.Interface)
+	common := filepath.Join(dir, "common.md")
+	assert.Equal(t, "canvasWidth", canvas.Vars[0].Name().Def)
+	assert.Equal(t, common+":4", canvas.Vars[0].SourceReference().RenamedBy)
+	assert.Equal(t, "canvas", canvas.Method[0].Name().Def)
+	assert.Equal(t, common+":5", canvas.Method[0].SourceReference().RenamedBy)
+	assert.Len(t, conv.Types["Opts"].(*types.Dictionary).Members, 0)
+}
+
+func TestApplyOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "common.md"), "@apply first\n")
+	md := "# ct\n\n.title = internal\n\n" +
+		"@define first\ndraw = First\n@end\n" +
+		"@define second(name)\ndraw = $name\nwidth = $name\n@end\n\n" +
+		"## Canvas\n\n@include common.md\n@apply second(Second)\nwidth = Last\n"
+	conv, _, err := executeTransformFile(filepath.Join(dir, "ct.md"), md, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	canvas := conv.Types["Canvas"].(*types.Interface)
+	assert.Equal(t, "Second", canvas.Method[0].Name().Def)
+	assert.Equal(t, filepath.Join(dir, "ct.md")+":9", canvas.Method[0].SourceReference().RenamedBy)
+	assert.Equal(t, "Last", canvas.Vars[0].Name().Def)
+}
+
+func TestIncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.md"), "@include b.md\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "@include a.md\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "@define x(a)\n$a = $b\n@end\n")
+	writeFile(t, filepath.Join(dir, "d.md"), "@define y\n.package = foo\n")
+	for md, msg := range map[string]string{
+		"@include a.md\n":                                 "include cycle",
+		"@include missing.md\n":                           "ct.md:5:1: unable to include file",
+		"@include c.md\n\n## Canvas\n\n@apply x(width)\n": "c.md:2:6: unknown parameter '$b'",
+		"@include c.md\n\n## Canvas\n\n@apply x\n":        "ct.md:9:1: rule group 'x' expects 1 argument(s), got 0",
+		"@include d.md\n":                                 "d.md:1:1: missing @end",
+		"@apply z\n":                                      "ct.md:5:1: unknown rule group 'z'",
+		"@end\n":                                          "ct.md:5:2: @end without @define",
+		"@define r\n@apply r\n@end\n\n## Canvas\n\n@apply r\n": "too many nested @apply",
+		"@define a\n@define b\n@end\n":                         "ct.md:6:1: @define inside @define",
+	} {
+		text := "# ct\n\n.title = internal\n\n" + md
+		_, _, err := executeTransformFile(filepath.Join(dir, "ct.md"), text, t)
+		if assert.NotNil(t, err, md) {
+			assert.Contains(t, err.Error(), msg)
+		}
+	}
+}
+
+func TestIncludeToJSON(t *testing.T) {
+	for _, md := range []string{
+		"@include common.md\n",
+		"@define x\n.name = X\n@end\n",
+		"@apply x\n",
+	} {
+		_, err := ConvertToJSON("ct.md", []byte("# ct\n\n"+md))
+		if assert.NotNil(t, err, md) {
+			assert.Contains(t, err.Error(), "json format doesn't support it")
+		}
+	}
+}
+
+func writeFile(t *testing.T, filename, content string) {
+	if err := ioutil.WriteFile(filename, []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -21,11 +21,14 @@ func _() {
 	_ = x[itemCommand-10]
 	_ = x[itemWord-11]
 	_ = x[itemKeyword-12]
+	_ = x[itemCode-13]
+	_ = x[itemPattern-14]
+	_ = x[itemGroupBody-15]
 }
 
-const _itemType_name = "itemErroritemEOFitemNewLineitemSpecialitemIdentitemCommentitemFileHeaderitemTypeHeaderitemStringitemValueitemCommanditemWorditemKeyword"
+const _itemType_name = "itemErroritemEOFitemNewLineitemSpecialitemIdentitemCommentitemFileHeaderitemTypeHeaderitemStringitemValueitemCommanditemWorditemKeyworditemCodeitemPatternitemGroupBody"
 
-var _itemType_index = [...]uint8{0, 9, 16, 27, 38, 47, 58, 72, 86, 96, 105, 116, 124, 135}
+var _itemType_index = [...]uint8{0, 9, 16, 27, 38, 47, 58, 72, 86, 96, 105, 116, 124, 135, 143, 154, 167}
 
 func (i itemType) String() string {
 	if i < 0 || i >= itemType(len(_itemType_index)-1) {
@@ -0,0 +1,566 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonTransform is a transform file in json format, an alternative
+// to the markdown syntax that is easier to generate from tools
+type jsonTransform struct {
+	File  *jsonSection   `json:"file"`
+	Types []*jsonSection `json:"types,omitempty"`
+}
+
+// jsonSection is the file section or a type section. Name is a type
+// name, a wildcard pattern or a regular expression inside /.../
+type jsonSection struct {
+	Name    string        `json:"name,omitempty"`
+	Actions []*jsonAction `json:"actions,omitempty"`
+
+	line int
+}
+
+// jsonAction is a single line in a section, only one of the fields
+// can be used
+type jsonAction struct {
+	Property   *jsonNameValue `json:"property,omitempty"`
+	Rename     *jsonNameValue `json:"rename,omitempty"`
+	Replace    *jsonReplace   `json:"replace,omitempty"`
+	ChangeType *jsonNameValue `json:"changetype,omitempty"`
+	Event      *jsonEvent     `json:"event,omitempty"`
+	AddEvent   *jsonEvent     `json:"addevent,omitempty"`
+	NotEvent   *string        `json:"notevent,omitempty"`
+	EventProp  []arg          `json:"eventprop,omitempty"`
+	Skip       *string        `json:"skip,omitempty"`
+	Patch      *string        `json:"patch,omitempty"`
+	Code       *string        `json:"code,omitempty"`
+	On         *jsonOn        `json:"on,omitempty"`
+
+	// line is the start of the action and codeLine the code string
+	line, codeLine int
+}
+
+type jsonNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type jsonReplace struct {
+	Property string `json:"property"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+type jsonEvent struct {
+	Method string `json:"method"`
+	Type   string `json:"type"`
+	Args   []arg  `json:"args,omitempty"`
+}
+
+// jsonOn is a @on rule. Kind is one of interface, enum, callback
+// and dictionary for a type rule or attribute, operation, constant
+// and member for a member rule
+type jsonOn struct {
+	Kind    string        `json:"kind,omitempty"`
+	Filters []*jsonFilter `json:"filters,omitempty"`
+	Match   string        `json:"match"`
+	Do      *jsonAction   `json:"do"`
+}
+
+// jsonFilter is a member rule condition, Match is not used by static
+type jsonFilter struct {
+	What  string `json:"what"`
+	Match string `json:"match,omitempty"`
+}
+
+var jsonTypeKinds = map[string]matchType{
+	"":           matchAll,
+	"interface":  matchInterface,
+	"enum":       matchEnum,
+	"callback":   matchCallback,
+	"dictionary": matchDictionary,
+}
+
+var jsonMemberKinds = map[string]memberKind{
+	"attribute": memberAttribute,
+	"operation": memberOperation,
+	"constant":  memberConstant,
+	"member":    memberAny,
+}
+
+// parseJSON is reading a json transform file
+func parseJSON(filename string, content []byte, packageName string, t *Transform) error {
+	file, err := decodeJSON(filename, content)
+	if err != nil {
+		return err
+	}
+	lw := &lexWrap{
+		out:         t,
+		file:        filename,
+		packageName: packageName,
+	}
+	lw.line = file.File.line
+	lw.AddFile(lw.newFileHeader(), lw.jsonActions(file.File.Actions))
+	for _, s := range file.Types {
+		lw.line = s.line
+		var header *onType
+		if isPatternName(s.Name) {
+			header = lw.newPatternHeader(s.Name)
+		} else if isIdentifier(s.Name) {
+			header = lw.newTypeHeader(s.Name)
+		} else {
+			lw.messageError("invalid type name '%s'", s.Name)
+			continue
+		}
+		lw.AddType(header, lw.jsonActions(s.Actions))
+	}
+	if t.errors > 0 {
+		return fmt.Errorf("stop reading from previous error")
+	}
+	return nil
+}
+
+func isPatternName(name string) bool {
+	if len(name) > 2 && strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/") {
+		return true
+	}
+	return strings.ContainsAny(name, "*?")
+}
+
+func (lw *lexWrap) jsonActions(list []*jsonAction) []action {
+	ret := []action{}
+	for _, a := range list {
+		lw.line = a.line
+		if value := lw.jsonAction(a); value != nil {
+			ret = append(ret, value)
+		}
+	}
+	return ret
+}
+
+// jsonAction is converting a json action using same functions as
+// the markdown parser
+func (lw *lexWrap) jsonAction(a *jsonAction) action {
+	if a == nil || a.count() != 1 {
+		lw.messageError("expected exactly one command in action")
+		return nil
+	}
+	switch {
+	case a.Property != nil:
+		return lw.newProperty(a.Property.Name, a.Property.Value)
+	case a.Rename != nil:
+		return lw.newRename(a.Rename.Name, a.Rename.Value)
+	case a.Replace != nil:
+		return lw.newReplace(a.Replace.Property, a.Replace.From, a.Replace.To)
+	case a.ChangeType != nil:
+		if a.ChangeType.Value == "rawjs" {
+			return lw.newChangeType(a.ChangeType.Name, "rawjs", "")
+		}
+		return lw.newChangeType(a.ChangeType.Name, "", a.ChangeType.Value)
+	case a.Event != nil:
+		return lw.newEvent(a.Event.Method, a.Event.Type, a.Event.Args)
+	case a.AddEvent != nil:
+		return lw.addEvent(a.AddEvent.Method, a.AddEvent.Type, a.AddEvent.Args)
+	case a.NotEvent != nil:
+		return lw.notEvent(*a.NotEvent)
+	case a.EventProp != nil:
+		return lw.setEventProp(a.EventProp)
+	case a.Skip != nil:
+		return lw.newSkip(*a.Skip)
+	case a.Patch != nil:
+		if *a.Patch != "idlconst" {
+			lw.messageError("unknown patch '%s', valid is idlconst", *a.Patch)
+			return nil
+		}
+		return lw.newPatchIdlConst()
+	case a.Code != nil:
+		lw.codeRef = lw.lineRef(lw.input, a.codeLine)
+		return lw.newCode(*a.Code)
+	}
+	return lw.jsonOn(a.On)
+}
+
+func (lw *lexWrap) jsonOn(on *jsonOn) action {
+	with := lw.jsonAction(on.Do)
+	if with == nil {
+		return nil
+	}
+	if what, found := jsonTypeKinds[on.Kind]; found {
+		if len(on.Filters) > 0 {
+			lw.messageError("filters can only be used on member rules")
+			return nil
+		}
+		if on.Do.Skip != nil && *on.Do.Skip == "" {
+			lw.messageError("skip without a name can only be used on member rules")
+			return nil
+		}
+		return lw.newOn(what, on.Match, with)
+	}
+	kind, found := jsonMemberKinds[on.Kind]
+	if !found {
+		lw.messageError("unknown @on kind '%s'", on.Kind)
+		return nil
+	}
+	if on.Do.Property == nil && on.Do.Skip == nil {
+		lw.messageError("member rule can only use .name or @skip")
+		return nil
+	}
+	filters := []memberFilter{}
+	for _, f := range on.Filters {
+		switch {
+		case f.What == "static" && f.Match == "":
+		case f.What == "returns", f.What == "param", f.What == "extattr", f.What == "type":
+		default:
+			lw.messageError("invalid member filter '%s'", f.What)
+			return nil
+		}
+		filters = append(filters, lw.newMemberFilter(f.What, f.Match))
+	}
+	return lw.newOnMember(kind, filters, on.Match, with)
+}
+
+func (a *jsonAction) count() int {
+	count := 0
+	for _, used := range []bool{
+		a.Property != nil, a.Rename != nil, a.Replace != nil, a.ChangeType != nil,
+		a.Event != nil, a.AddEvent != nil, a.NotEvent != nil, a.EventProp != nil,
+		a.Skip != nil, a.Patch != nil, a.Code != nil, a.On != nil,
+	} {
+		if used {
+			count++
+		}
+	}
+	return count
+}
+
+// jsonReader is decoding a json transform file and remember the
+// line of every section and action
+type jsonReader struct {
+	dec      *json.Decoder
+	data     []byte
+	filename string
+}
+
+func decodeJSON(filename string, content []byte) (*jsonTransform, error) {
+	r := &jsonReader{
+		dec:      json.NewDecoder(bytes.NewReader(content)),
+		data:     content,
+		filename: filename,
+	}
+	r.dec.DisallowUnknownFields()
+	ret := &jsonTransform{}
+	err := r.object(func(key string) error {
+		switch key {
+		case "file":
+			section, err := r.section()
+			ret.File = section
+			return err
+		case "types":
+			return r.array(func() error {
+				section, err := r.section()
+				ret.Types = append(ret.Types, section)
+				return err
+			})
+		}
+		return r.errorf("unknown field '%s'", key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ret.File == nil {
+		return nil, fmt.Errorf("%s: missing file section", filename)
+	}
+	return ret, nil
+}
+
+func (r *jsonReader) section() (*jsonSection, error) {
+	ret := &jsonSection{line: r.nextLine()}
+	err := r.object(func(key string) error {
+		switch key {
+		case "name":
+			return r.decode(&ret.Name)
+		case "actions":
+			return r.array(func() error {
+				start := r.nextOffset()
+				a := &jsonAction{line: r.nextLine()}
+				if err := r.decode(a); err != nil {
+					return err
+				}
+				raw := r.data[start:r.dec.InputOffset()]
+				a.setLine(a.line, r.lineAt(start+fieldOffset(raw, "code")))
+				ret.Actions = append(ret.Actions, a)
+				return nil
+			})
+		}
+		return r.errorf("unknown field '%s'", key)
+	})
+	return ret, err
+}
+
+// setLine is giving the action after ':' in @on same line
+func (a *jsonAction) setLine(line, codeLine int) {
+	a.line, a.codeLine = line, codeLine
+	if a.On != nil && a.On.Do != nil {
+		a.On.Do.setLine(line, codeLine)
+	}
+}
+
+// fieldOffset is the offset of the value of first field with given
+// name in a json value, at any depth. Zero is returned if not found
+func fieldOffset(raw []byte, name string) int {
+	type level struct{ object, key bool }
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	var stack []*level
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0
+		}
+		var top *level
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		switch {
+		case tok == json.Delim('{'):
+			stack = append(stack, &level{object: true, key: true})
+			continue
+		case tok == json.Delim('['):
+			stack = append(stack, &level{})
+			continue
+		case tok == json.Delim('}'), tok == json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		case top != nil && top.key:
+			if tok == name {
+				return int(dec.InputOffset())
+			}
+			top.key = false
+			continue
+		}
+		// a value is done, an object is expecting next field name
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].key = true
+		}
+	}
+}
+
+func (r *jsonReader) object(field func(key string) error) error {
+	if err := r.delim('{'); err != nil {
+		return err
+	}
+	for r.dec.More() {
+		tok, err := r.token()
+		if err != nil {
+			return err
+		}
+		if err := field(tok.(string)); err != nil {
+			return err
+		}
+	}
+	return r.delim('}')
+}
+
+func (r *jsonReader) array(value func() error) error {
+	if err := r.delim('['); err != nil {
+		return err
+	}
+	for r.dec.More() {
+		if err := value(); err != nil {
+			return err
+		}
+	}
+	return r.delim(']')
+}
+
+func (r *jsonReader) delim(expected json.Delim) error {
+	line := r.nextLine()
+	tok, err := r.token()
+	if err != nil {
+		return err
+	}
+	if tok != expected {
+		return fmt.Errorf("%s:%d: expected '%s', found %v", r.filename, line, expected, tok)
+	}
+	return nil
+}
+
+func (r *jsonReader) token() (json.Token, error) {
+	tok, err := r.dec.Token()
+	if err != nil {
+		return nil, r.errorf("%s", err)
+	}
+	return tok, nil
+}
+
+func (r *jsonReader) decode(value interface{}) error {
+	line := r.nextLine()
+	if err := r.dec.Decode(value); err != nil {
+		return fmt.Errorf("%s:%d: %s", r.filename, line, err)
+	}
+	return nil
+}
+
+// nextLine is the line where next value starts
+func (r *jsonReader) nextLine() int {
+	return r.lineAt(r.nextOffset())
+}
+
+// nextOffset is the offset where next value starts
+func (r *jsonReader) nextOffset() int {
+	return r.skipSpace(int(r.dec.InputOffset()))
+}
+
+// lineAt is the line of the value at given offset
+func (r *jsonReader) lineAt(pos int) int {
+	return bytes.Count(r.data[:r.skipSpace(pos)], []byte("\n")) + 1
+}
+
+func (r *jsonReader) skipSpace(pos int) int {
+	for pos < len(r.data) && strings.IndexByte(" \t\r\n,:", r.data[pos]) != -1 {
+		pos++
+	}
+	return pos
+}
+
+func (r *jsonReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d: %s", r.filename, r.nextLine(), fmt.Sprintf(format, args...))
+}
+
+// ConvertToJSON is parsing a markdown transform file and return it
+// in json format. comments are lost and @include, @define and @apply
+// is an error as there is nothing similar in the json format
+func ConvertToJSON(filename string, content []byte) ([]byte, error) {
+	t := New()
+	lw := newLexWrap(filename, string(content), "", t)
+	lw.toJSON = true
+	if err := lw.parse(); err != nil {
+		return nil, err
+	}
+	return encodeJSON(t)
+}
+
+// encodeJSON is writing all sections, in input order
+func encodeJSON(t *Transform) ([]byte, error) {
+	out := &jsonTransform{File: &jsonSection{}}
+	for _, f := range t.Global {
+		list, err := jsonFromActions(f.Actions)
+		if err != nil {
+			return nil, err
+		}
+		out.File.Actions = append(out.File.Actions, list...)
+	}
+	sections := append([]*onType{}, t.Patterns...)
+	for _, s := range t.All {
+		sections = append(sections, s)
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		a, b := sections[i].Ref, sections[j].Ref
+		if a.inputFile() != b.inputFile() {
+			return a.inputFile() < b.inputFile()
+		}
+		return a.Line < b.Line
+	})
+	for _, s := range sections {
+		list, err := jsonFromActions(s.Actions)
+		if err != nil {
+			return nil, err
+		}
+		out.Types = append(out.Types, &jsonSection{Name: s.Name, Actions: list})
+	}
+	content, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+func jsonFromActions(list []action) ([]*jsonAction, error) {
+	ret := []*jsonAction{}
+	for _, a := range list {
+		value, err := jsonFromAction(a)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, value)
+	}
+	return ret, nil
+}
+
+func jsonFromAction(a action) (*jsonAction, error) {
+	text := func(value string) *string { return &value }
+	switch a := a.(type) {
+	case *property:
+		return &jsonAction{Property: &jsonNameValue{Name: a.Name, Value: a.Value}}, nil
+	case *rename:
+		return &jsonAction{Rename: &jsonNameValue{Name: a.Name, Value: a.Value}}, nil
+	case *replace:
+		return &jsonAction{Replace: &jsonReplace{Property: a.Property, From: a.From, To: a.To}}, nil
+	case *changeType:
+		value := a.IdlType
+		if a.RawJS != "" {
+			value = a.RawJS
+		}
+		return &jsonAction{ChangeType: &jsonNameValue{Name: a.Name, Value: value}}, nil
+	case *event:
+		return &jsonAction{Event: jsonFromEvent(&a.commonEventData)}, nil
+	case *addevent:
+		return &jsonAction{AddEvent: jsonFromEvent(&a.commonEventData)}, nil
+	case *notEvent:
+		return &jsonAction{NotEvent: text(a.AttributeName)}, nil
+	case *setEventProp:
+		return &jsonAction{EventProp: a.Args}, nil
+	case *skip:
+		return &jsonAction{Skip: text(a.Name)}, nil
+	case *idlconst:
+		return &jsonAction{Patch: text("idlconst")}, nil
+	case *injectCode:
+		return &jsonAction{Code: text(a.Code)}, nil
+	case *globalRegExp:
+		with, err := jsonFromAction(a.What)
+		if err != nil {
+			return nil, err
+		}
+		on := &jsonOn{Match: a.Match.String(), Do: with}
+		for name, what := range jsonTypeKinds {
+			if what == a.Type {
+				on.Kind = name
+			}
+		}
+		return &jsonAction{On: on}, nil
+	case *memberRule:
+		on := &jsonOn{Match: a.Match.String()}
+		for _, f := range a.Filters {
+			filter := &jsonFilter{What: f.What}
+			if f.Match != nil {
+				filter.Match = f.Match.String()
+			}
+			on.Filters = append(on.Filters, filter)
+		}
+		for name, kind := range jsonMemberKinds {
+			if kind == a.Kind {
+				on.Kind = name
+			}
+		}
+		if a.Skip {
+			on.Do = &jsonAction{Skip: text("")}
+		} else {
+			on.Do = &jsonAction{Property: &jsonNameValue{Name: "name", Value: a.Rename}}
+		}
+		return &jsonAction{On: on}, nil
+	}
+	return nil, fmt.Errorf("%s: unable to convert %T to json", a.Reference(), a)
+}
+
+func jsonFromEvent(ev *commonEventData) *jsonEvent {
+	ret := &jsonEvent{Method: ev.Method, Type: ev.EventType}
+	if ev.Bubbles {
+		ret.Args = append(ret.Args, arg{Name: "bubbles", Value: "true"})
+	}
+	if ev.Cancelable {
+		ret.Args = append(ret.Args, arg{Name: "cancelable", Value: "true"})
+	}
+	return ret
+}
@@ -0,0 +1,129 @@
+package transform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/gowebapi/webidl-bind/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonTestTransform = `# ct
+
+.title = internal
+@on interface "^Blob$": .name = Data
+@on operation returns "^any$" "^(value)$": .name = Get${1}
+@on attribute static "x": @skip
+
+## Canvas
+
+@changetype width unsigned long
+draw = Paint
+@replace .name "Can" "Pan"
+@skip listen
+
+## Opts
+
+@changetype size rawjs
+`
+
+func TestJSONFormat(t *testing.T) {
+	content, err := ConvertToJSON("ct.md", []byte(jsonTestTransform))
+	if !assert.Nil(t, err) {
+		return
+	}
+	conv, trans, err := executeJSON(string(content), t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	canvas := conv.Types["Canvas"].(*types.Interface)
+	assert.Equal(t, "Panvas", canvas.Basic().Def)
+	assert.Equal(t, "unsigned long", canvas.Vars[0].Type.Basic().Idl)
+	assert.Equal(t, "Paint", canvas.Method[0].Name().Def)
+	assert.Equal(t, "Getvalue", canvas.Method[1].Name().Def)
+	assert.Len(t, canvas.Method, 2)
+	assert.Equal(t, "Data", conv.Types["Blob"].Basic().Def)
+	assert.IsType(t, &types.RawJSType{}, conv.Types["Opts"].(*types.Dictionary).Members[0].Type)
+
+	// json -> json should give same result
+	again, err := encodeJSON(trans)
+	assert.Nil(t, err)
+	assert.Equal(t, string(content), string(again))
+
+	// and all references point to json file
+	assert.Equal(t, "ct.json:66", canvas.Method[0].SourceReference().RenamedBy)
+}
+
+func TestJSONCodeRef(t *testing.T) {
+	content := `{"file": {"actions": [{"property": {"name": "title", "value": "internal"}}]},
+"types": [{"name": "Canvas", "actions": [
+	{
+		"code": "const CanvasSize = 4\n"
+	}
+]}]}`
+	conv, _, err := executeJSON(content, t)
+	if !assert.Nil(t, err) {
+		return
+	}
+	list := conv.Types["Canvas"].InjectedCode()
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "ct.json:4", list[0].Ref.String())
+	}
+}
+
+func TestJSONLoadSuffix(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(`{"file": {"actions": [{"property": {"name": "title", "value": "internal"}}]}}`)
+	for _, name := range []string{"ct.transform.json", "ct.json"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Nil(t, New().Load(filepath.Join(dir, "ct.transform.json"), "ct"))
+	err := New().Load(filepath.Join(dir, "ct.json"), "ct")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must end with .transform.json")
+	}
+}
+
+func TestJSONFormatErrors(t *testing.T) {
+	for _, input := range []string{
+		`{}`,
+		`{"file": {}, "unknown": 1}`,
+		`{"file": {"actions": [{"rename": {"name": "a", "value": "b"}, "skip": "c"}]}}`,
+		`{"file": {"actions": [{"foo": 1}]}}`,
+		`{"file": {}, "types": [{"name": "a b"}]}`,
+		`{"file": {"actions": [{"on": {"kind": "unknown", "match": "x", "do": {"skip": ""}}}]}}`,
+		`{"file": {"actions": [{"on": {"match": "x", "filters": [{"what": "static"}], "do": {"skip": ""}}}]}}`,
+		`{"file": {}, "types": [{"name": "Canvas", "actions": [{"patch": "other"}]}]}`,
+		`{"file": {"actions": [{"on": {"kind": "operation", "match": "^get", "do": {"rename": {"name": "a", "value": "b"}}}}]}}`,
+		`{"file": {`,
+	} {
+		_, _, err := executeJSON(input, t)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func executeJSON(content string, t *testing.T) (*types.Convert, *Transform, error) {
+	conv := types.NewConvert()
+	setup := &types.Setup{
+		Filename: "ct.idl",
+		Package:  "ct",
+		Error: func(ref types.GetRef, format string, args ...interface{}) {
+			t.Errorf(format, args...)
+		},
+		Warning: func(ref types.GetRef, format string, args ...interface{}) {},
+	}
+	if err := conv.Parse([]byte(changeTypeIdl), setup); err != nil {
+		return nil, nil, err
+	}
+	if err := conv.Evaluate(); err != nil {
+		return nil, nil, err
+	}
+	trans := New()
+	if err := parseJSON("ct.json", []byte(content), "ct", trans); err != nil {
+		return nil, nil, err
+	}
+	return conv, trans, trans.Execute(conv)
+}
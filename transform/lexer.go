@@ -25,12 +25,16 @@ const (
 	itemCommand
 	itemWord
 	itemKeyword
+	itemCode
+	itemPattern
+	itemGroupBody
 )
 
 type item struct {
 	typ  itemType
 	val  string
 	line int
+	pos  int // byte offset in input
 }
 
 func (i item) String() string {
@@ -85,6 +89,11 @@ func (l *lexer) nextItem() item {
 		case item := <-l.items:
 			return item
 		default:
+			if l.state == nil && l.fail {
+				// continue on next line after an error
+				l.fail = false
+				l.state = lexSkipLine
+			}
 			l.state = l.state(l)
 		}
 	}
@@ -99,7 +108,7 @@ func (l *lexer) emit(t itemType) {
 		// line number in next() before doing evaluation of rune
 		line--
 	}
-	l.items <- item{t, l.input[l.start:l.pos], line}
+	l.items <- item{t, l.input[l.start:l.pos], line, l.start}
 	l.start = l.pos
 }
 
@@ -156,17 +165,44 @@ func (l *lexer) next() (ch rune) {
 
 // error returns an error token and terminates the scan
 // by passing back a nil pointer that will be the next
-// state, terminating l.run.
+// state. nextItem will continue on next line.
 func (l *lexer) errorf(format string, args ...interface{}) stateFn {
+	if l.fail {
+		// only first error on a line
+		return nil
+	}
+	if l.wasNL {
+		// the new line is used to recover from the error
+		l.backup()
+	}
 	l.items <- item{
 		itemError,
 		fmt.Sprintf(format, args...),
 		l.line,
+		l.start,
 	}
 	l.fail = true
 	return nil
 }
 
+// lexSkipLine is ignoring the remaining of a line with an error
+func lexSkipLine(l *lexer) stateFn {
+	for {
+		ch := l.next()
+		if ch == eof {
+			l.ignore()
+			l.emit(itemNewLine)
+			l.emit(itemEOF)
+			return nil
+		}
+		if ch == '\n' {
+			l.ignore()
+			l.emit(itemNewLine)
+			return lexLineStart
+		}
+	}
+}
+
 // accept consumes the next rune
 // if it's from the valid set.
 func (l *lexer) accept(valid string) bool {
@@ -223,6 +259,21 @@ func (l *lexer) evalWord(expected string, accept bool) bool {
 	return true
 }
 
+// readLine is consuming the remaining of current line, including
+// the new line. return false if end of input was reached
+func (l *lexer) readLine() (string, bool) {
+	start := l.pos
+	for {
+		ch := l.next()
+		if ch == '\n' {
+			return l.input[start : l.pos-1], true
+		}
+		if ch == eof {
+			return l.input[start:l.pos], false
+		}
+	}
+}
+
 func (l *lexer) acceptWord(expected string) bool {
 	return l.evalWord(expected, true)
 }
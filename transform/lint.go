@@ -0,0 +1,149 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// LintMessage is a finding from Lint
+type LintMessage struct {
+	Filename string
+	Line     int
+	Text     string
+}
+
+func (m *LintMessage) String() string {
+	return fmt.Sprintf("%s:%d: %s", m.Filename, m.Line, m.Text)
+}
+
+// lintData is collected while executing the transformation
+type lintData struct {
+	matched map[ref]int
+	changes map[ref]*ruleCount
+	renames map[*types.Ref][]renameRecord
+	targets []*types.Ref
+}
+
+// ruleCount is how many times a rule was applied and how many of
+// them that didn't change anything
+type ruleCount struct {
+	applied   int
+	unchanged int
+}
+
+// renameRecord is a rule that renamed a type or member
+type renameRecord struct {
+	name  string
+	rule  ref
+	value string
+}
+
+func (t *Transform) matched(rule ref) {
+	if t.lint.matched == nil {
+		t.lint.matched = make(map[ref]int)
+	}
+	t.lint.matched[rule]++
+}
+
+func (t *Transform) applied(rule ref, changed bool) {
+	if t.lint.changes == nil {
+		t.lint.changes = make(map[ref]*ruleCount)
+	}
+	count, found := t.lint.changes[rule]
+	if !found {
+		count = &ruleCount{}
+		t.lint.changes[rule] = count
+	}
+	count.applied++
+	if !changed {
+		count.unchanged++
+	}
+}
+
+func (t *Transform) renamed(target *types.Ref, name string, rule ref, value string) {
+	if target == nil {
+		return
+	}
+	if t.lint.renames == nil {
+		t.lint.renames = make(map[*types.Ref][]renameRecord)
+	}
+	list, found := t.lint.renames[target]
+	if !found {
+		t.lint.targets = append(t.lint.targets, target)
+	}
+	for _, r := range list {
+		if r.rule == rule {
+			return
+		}
+	}
+	t.lint.renames[target] = append(list, renameRecord{name: name, rule: rule, value: value})
+}
+
+// Lint is returning rules that didn't match anything, rules that is
+// setting a value that is already in use and types or members that
+// is renamed by more than one rule. Execute must be called first.
+func (t *Transform) Lint() []*LintMessage {
+	ret := []*LintMessage{}
+	add := func(at ref, format string, args ...interface{}) {
+		ret = append(ret, &LintMessage{
+			Filename: at.Filename,
+			Line:     at.Line,
+			Text:     fmt.Sprintf(format, args...),
+		})
+	}
+	sections := append([]*onType{}, t.Global...)
+	sections = append(sections, t.Patterns...)
+	for _, change := range t.All {
+		sections = append(sections, change)
+	}
+	for _, change := range sections {
+		for _, a := range change.Actions {
+			t.lintAction(a, add)
+		}
+	}
+	for _, target := range t.lint.targets {
+		list := t.lint.renames[target]
+		for i := 1; i < len(list); i++ {
+			add(list[i].rule, "'%s' renamed to '%s' is also renamed to '%s' by %s",
+				list[i].name, list[i].value, list[i-1].value, list[i-1].rule)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Filename != ret[j].Filename {
+			return ret[i].Filename < ret[j].Filename
+		}
+		return ret[i].Line < ret[j].Line
+	})
+	return ret
+}
+
+func (t *Transform) lintAction(a action, add func(at ref, format string, args ...interface{})) {
+	switch a := a.(type) {
+	case *globalRegExp:
+		if t.lint.matched[a.Ref] == 0 {
+			add(a.Ref, "regexp rule '%s' doesn't match any type", a.Match)
+			return
+		}
+		t.lintAction(a.What, add)
+	case *memberRule:
+		if t.lint.matched[a.Ref] == 0 {
+			add(a.Ref, "member rule '%s' doesn't match any member", a.Match)
+			return
+		}
+		t.lintUnchanged(a.Ref, "name", add)
+	case *property:
+		t.lintUnchanged(a.Ref, "."+a.Name, add)
+	case *rename:
+		t.lintUnchanged(a.Ref, a.Name, add)
+	}
+}
+
+// lintUnchanged is reporting a rule where every application was
+// setting the value it already had
+func (t *Transform) lintUnchanged(at ref, what string, add func(at ref, format string, args ...interface{})) {
+	if count, found := t.lint.changes[at]; found && count.applied == count.unchanged {
+		add(at, "'%s' is set to the value it already has", what)
+	}
+}
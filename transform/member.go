@@ -0,0 +1,186 @@
+package transform
+
+import (
+	"regexp"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// memberKind is what kind of members a member rule is selecting
+type memberKind int
+
+const (
+	memberAny memberKind = iota
+	memberAttribute
+	memberOperation
+	memberConstant
+)
+
+// memberFilter is an extra condition in a member rule, e.g.
+// returns "^Promise"
+type memberFilter struct {
+	What  string
+	Match *regexp.Regexp
+}
+
+// memberRule is a @on rule that rename or skip all members that
+// match, e.g. @on operation returns "^Promise" "^get(.+)$": .name = ${1}Async
+type memberRule struct {
+	abstractAction
+	Kind    memberKind
+	Match   *regexp.Regexp
+	Filters []memberFilter
+
+	// Rename is the new name, can reference sub matches in Match
+	Rename string
+	Skip   bool
+}
+
+// ruleMember is a member as seen by a member rule
+type ruleMember struct {
+	kind   memberKind
+	name   *types.MethodName
+	ref    *types.Ref
+	static bool
+	typ    types.TypeRef
+	params []*types.Parameter
+	attrs  []string
+}
+
+func (t *memberRule) OperateOn() scopeMode {
+	return scopeGlobal
+}
+
+func (t *memberRule) ExecuteCallback(instance *types.Callback, data *actionData) {
+}
+
+func (t *memberRule) ExecuteEnum(instance *types.Enum, data *actionData) {
+}
+
+func (t *memberRule) ExecuteDictionary(value *types.Dictionary, data *actionData) {
+	list := []*ruleMember{}
+	for _, m := range value.Members {
+		list = append(list, &ruleMember{
+			kind:  memberAttribute,
+			name:  m.Name(),
+			ref:   m.SourceReference(),
+			typ:   m.Type,
+			attrs: m.ExtendedAttributes(),
+		})
+	}
+	t.execute(value, list, data)
+}
+
+func (t *memberRule) ExecuteInterface(value *types.Interface, data *actionData) {
+	list := []*ruleMember{}
+	for _, c := range value.Consts {
+		list = append(list, &ruleMember{
+			kind:   memberConstant,
+			name:   c.Name(),
+			ref:    c.SourceReference(),
+			static: true,
+			typ:    c.Type,
+			attrs:  c.ExtendedAttributes(),
+		})
+	}
+	for _, vars := range [][]*types.IfVar{value.Vars, value.StaticVars} {
+		for _, v := range vars {
+			list = append(list, &ruleMember{
+				kind:   memberAttribute,
+				name:   v.Name(),
+				ref:    v.SourceReference(),
+				static: v.Static,
+				typ:    v.Type,
+				attrs:  v.ExtendedAttributes(),
+			})
+		}
+	}
+	for _, methods := range [][]*types.IfMethod{value.Method, value.StaticMethod} {
+		for _, m := range methods {
+			list = append(list, &ruleMember{
+				kind:   memberOperation,
+				name:   m.Name(),
+				ref:    m.SourceReference(),
+				static: m.Static,
+				typ:    m.Return,
+				params: m.Params,
+				attrs:  m.ExtendedAttributes(),
+			})
+		}
+	}
+	t.execute(value, list, data)
+}
+
+func (t *memberRule) execute(value types.Type, list []*ruleMember, data *actionData) {
+	skipped := make(map[string]bool)
+	for _, m := range list {
+		if !t.selected(value, m) {
+			continue
+		}
+		m.ref.AddTrace("matched member rule '%s' at %s", t.Match, t.Ref)
+		data.notify.matched(t.Ref)
+		if t.Skip {
+			skipped[m.name.Idl] = true
+			continue
+		}
+		idx := t.Match.FindStringSubmatchIndex(m.name.Idl)
+		name := string(t.Match.ExpandString(nil, t.Rename, m.name.Idl, idx))
+		data.notify.applied(t.Ref, m.name.Def != name)
+		data.notify.renamed(m.ref, m.name.Idl, t.Ref, name)
+		m.name.Def = name
+		m.ref.RenamedBy = t.Ref.String()
+		m.ref.AddTrace("renamed to %s by %s", name, t.Ref)
+	}
+	for name := range skipped {
+		action := &skip{abstractAction: t.abstractAction, Name: name}
+		switch value := value.(type) {
+		case *types.Interface:
+			action.ExecuteInterface(value, data)
+		case *types.Dictionary:
+			action.ExecuteDictionary(value, data)
+		}
+	}
+}
+
+// selected is evaluating all conditions on a member
+func (t *memberRule) selected(value types.Type, m *ruleMember) bool {
+	if t.Kind != memberAny && t.Kind != m.kind {
+		return false
+	}
+	if !t.Match.MatchString(m.name.Idl) {
+		return false
+	}
+	for _, f := range t.Filters {
+		switch f.What {
+		case "static":
+			if !m.static {
+				return false
+			}
+		case "type":
+			if !f.Match.MatchString(value.Basic().Idl) {
+				return false
+			}
+		case "returns":
+			if m.typ == nil || !f.Match.MatchString(m.typ.Basic().Idl) {
+				return false
+			}
+		case "param":
+			found := false
+			for _, p := range m.params {
+				found = found || f.Match.MatchString(p.Type.Basic().Idl)
+			}
+			if !found {
+				return false
+			}
+		case "extattr":
+			found := false
+			for _, a := range m.attrs {
+				found = found || f.Match.MatchString(a)
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -75,6 +75,7 @@ func innerMethodRenameLogic(m *types.IfMethod, methods map[string]int) {
 			}
 			break
 		}
+		m.SourceReference().AddTrace("override renamed from %s to %s", m.Name().Def, name)
 		m.Name().Def = name
 		methods[name] = idx
 	} else {
@@ -4,19 +4,52 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 //go:generate ../../../../../bin/goyacc -o yacc.go -p transform yacc.y
 //go:generate ../../../../../bin/stringer -type itemType
 
 type lexWrap struct {
-	lex  *lexer
-	err  string
 	eof  bool
 	file string
 	line int
 	out  *Transform
 
+	// last is the item last returned to the parser
+	last item
+
+	// lexFailed is set when the lexer found an error that is
+	// already reported
+	lexFailed bool
+
+	// syntax is all syntax errors found
+	syntax SyntaxErrors
+
+	// codeRef is the start of last code block
+	codeRef ref
+
+	// stack is the input file and all included files and applied
+	// rule groups currently read, input is where last item is from
+	stack []*lexInput
+	input *lexInput
+
+	// pending is an included file or applied rule group that is
+	// read after the end of current line
+	pending *lexInput
+
+	// command is the last @include, @define or @apply
+	command      item
+	commandInput *lexInput
+
+	// groups is all rule groups defined with @define
+	groups map[string]*ruleGroup
+
+	// toJSON is set when the json format is written, it doesn't
+	// have @include, @define and @apply
+	toJSON bool
+
 	packageName string
 }
 
@@ -27,8 +60,13 @@ type parseResult interface {
 
 	newFileHeader() *onType
 	newTypeHeader(name string) *onType
-	newChangeType(method, typ string) action
+	newPatternHeader(pattern string) *onType
+	newChangeType(method, rawjs, idlType string) action
+	newSkip(name string) action
+	newCode(code string) action
 	newOn(match matchType, expr string, with action) action
+	newOnMember(kind memberKind, filters []memberFilter, expr string, with action) action
+	newMemberFilter(what, expr string) memberFilter
 	newProperty(name, value string) action
 	newRename(name, value string) action
 	newPatchIdlConst() action
@@ -38,11 +76,14 @@ type parseResult interface {
 	notEvent(attributeName string) action
 	setEventProp(args []arg) action
 	newArgumentIdent(name, value string) []arg
+	includeFile(filename string)
+	defineGroup(name string, params []string, body string)
+	applyGroup(name string, args []string)
 }
 
 type arg struct {
-	Name  string
-	Value string
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 var commandToken = map[string]int{
@@ -54,6 +95,11 @@ var commandToken = map[string]int{
 	"eventprop":  t_cmd_eventprop,
 	"addevent":   t_cmd_addevent,
 	"notevent":   t_cmd_notevent,
+	"skip":       t_cmd_skip,
+	"include":    t_cmd_include,
+	"define":     t_cmd_define,
+	"apply":      t_cmd_apply,
+	"end":        t_cmd_end,
 }
 
 var keywordToken = map[string]int{
@@ -63,6 +109,15 @@ var keywordToken = map[string]int{
 	"callback":   t_callback,
 	"idlconst":   t_idlconst,
 	"rawjs":      t_rawjs,
+	"attribute":  t_attribute,
+	"operation":  t_operation,
+	"constant":   t_constant,
+	"member":     t_member,
+	"static":     t_static,
+	"returns":    t_returns,
+	"param":      t_param,
+	"extattr":    t_extattr,
+	"type":       t_type,
 }
 
 // // Load is loading a file from disc and parse it
@@ -76,20 +131,28 @@ var keywordToken = map[string]int{
 
 // Parse is parsing a content string
 func parseText(filename, content, packageName string, t *Transform) error {
-	var err error
-	lw := &lexWrap{
-		lex:         newLex(filename, content),
+	return newLexWrap(filename, content, packageName, t).parse()
+}
+
+func newLexWrap(filename, content, packageName string, t *Transform) *lexWrap {
+	return &lexWrap{
 		out:         t,
 		file:        filename,
 		packageName: packageName,
+		stack:       []*lexInput{{lex: newLex(filename, content), file: filename}},
+		groups:      make(map[string]*ruleGroup),
 	}
+}
+
+func (lw *lexWrap) parse() error {
+	var err error
 	transformErrorVerbose = true
 	checkParseResultImpl(lw)
-	if code := transformParse(lw); lw.err != "" {
-		err = fmt.Errorf("%s:%d: lex parsing trouble: %s", filename, lw.line, lw.err)
+	if code := transformParse(lw); len(lw.syntax) > 0 {
+		err = lw.syntax
 	} else if code != 0 {
-		err = fmt.Errorf("%s:%d: yacc parsing trouble: %d", filename, lw.line, code)
-	} else if t.errors > 0 {
+		err = fmt.Errorf("%s: yacc parsing trouble: %d", lw.ref(), code)
+	} else if lw.out.errors > 0 {
 		err = fmt.Errorf("stop reading from previous error")
 	}
 	return err
@@ -108,19 +171,35 @@ func presult(lex transformLexer) parseResult {
 }
 
 func (lw *lexWrap) Lex(lval *transformSymType) int {
-	if lw.eof || lw.err != "" {
+	if lw.eof {
 		panic("repeated calling Lex after end")
 	}
 	ok := true
 	tok := 0
-	item := lw.lex.nextItem()
+	if lw.pending != nil && lw.last.typ == itemNewLine {
+		lw.stack = append(lw.stack, lw.pending)
+		lw.pending = nil
+	}
+	in := lw.stack[len(lw.stack)-1]
+	item := in.lex.nextItem()
+	for item.typ == itemEOF && len(lw.stack) > 1 {
+		// continue after the @include or @apply line
+		lw.stack = lw.stack[:len(lw.stack)-1]
+		in = lw.stack[len(lw.stack)-1]
+		item = in.lex.nextItem()
+	}
+	lw.input = in
 	lw.line = item.line
+	lw.last = item
+	lw.lexFailed = false
 	lval.val = item.val
 
 	// fmt.Println("lex: ", item.line, item.typ, item.val)
 	switch item.typ {
 	case itemError:
-		lw.err = item.val
+		lw.syntaxError(in, item, item.val, nil)
+		lw.lexFailed = true
+		tok = t_error
 	case itemEOF:
 		lw.eof = true
 	case itemNewLine:
@@ -147,6 +226,16 @@ func (lw *lexWrap) Lex(lval *transformSymType) int {
 		if !ok {
 			panic("unknown command: " + item.val)
 		}
+		if item.val == "include" || item.val == "define" || item.val == "apply" {
+			lw.command, lw.commandInput = item, in
+		}
+	case itemPattern:
+		tok = t_pattern
+	case itemCode:
+		tok = t_code
+		lw.codeRef = lw.lineRef(in, item.line)
+	case itemGroupBody:
+		tok = t_group_body
 	case itemKeyword:
 		tok, ok = keywordToken[item.val]
 		if !ok {
@@ -160,15 +249,59 @@ func (lw *lexWrap) Lex(lval *transformSymType) int {
 }
 
 func (lw *lexWrap) Error(s string) {
-	fmt.Println("parser error:", lw.line, ":", s)
-	lw.out.errors++
+	if lw.lexFailed {
+		// already reported by the lexer
+		lw.lexFailed = false
+		return
+	}
+	if lw.input == nil {
+		// not from a markdown file
+		lw.messageError("%s", s)
+		return
+	}
+	msg, expected := parseYaccMessage(s, lw.last)
+	lw.syntaxError(lw.input, lw.last, msg, expected)
+}
+
+// syntaxError is remembering an error with source line and column
+func (lw *lexWrap) syntaxError(in *lexInput, at item, msg string, expected []string) {
+	text := in.lex.input
+	pos := at.pos
+	if pos > len(text) {
+		pos = len(text)
+	}
+	start := strings.LastIndex(text[:pos], "\n") + 1
+	end := strings.Index(text[pos:], "\n")
+	if end == -1 {
+		end = len(text)
+	} else {
+		end += pos
+	}
+	ref := lw.lineRef(in, at.line)
+	lw.syntax = append(lw.syntax, &SyntaxError{
+		Filename: ref.Filename,
+		Line:     ref.Line,
+		Column:   utf8.RuneCountInString(text[start:pos]) + 1,
+		Message:  msg,
+		Expected: expected,
+		Source:   strings.TrimRight(text[start:end], "\r"),
+	})
 }
 
 func (lw *lexWrap) ref() ref {
-	return ref{
-		Filename: lw.file,
-		Line:     lw.line,
+	return lw.lineRef(lw.input, lw.line)
+}
+
+// lineRef is the file and line of a line read by given input
+func (lw *lexWrap) lineRef(in *lexInput, line int) ref {
+	if in == nil {
+		return ref{Filename: lw.file, Line: line}
 	}
+	ret := ref{Filename: in.file, Line: in.offset + line}
+	if in.file != lw.file {
+		ret.Input = lw.file
+	}
+	return ret
 }
 
 func (lw *lexWrap) AddFile(f *onType, section []action) {
@@ -178,6 +311,15 @@ func (lw *lexWrap) AddFile(f *onType, section []action) {
 
 func (lw *lexWrap) AddType(v *onType, section []action) {
 	v.Actions = section
+	if v.Match != nil {
+		for _, other := range lw.out.Patterns {
+			if other.Name == v.Name {
+				lw.messageError("type header already exist in %s:%d", other.Ref.Filename, other.Ref.Line)
+			}
+		}
+		lw.out.Patterns = append(lw.out.Patterns, v)
+		return
+	}
 	if other, exist := lw.out.All[v.Name]; exist {
 		lw.messageError("type already exist in %s:%d", other.Ref.Filename, other.Ref.Line)
 	}
@@ -200,17 +342,59 @@ func (lw *lexWrap) newTypeHeader(name string) *onType {
 	return ret
 }
 
-func (lw *lexWrap) newChangeType(method, typ string) action {
+func (lw *lexWrap) newPatternHeader(pattern string) *onType {
+	ret := &onType{
+		Name: pattern,
+		Ref:  lw.ref(),
+	}
+	expr := pattern
+	if strings.HasPrefix(pattern, "/") {
+		expr = pattern[1 : len(pattern)-1]
+	} else {
+		expr = regexp.QuoteMeta(pattern)
+		expr = strings.Replace(expr, `\*`, ".*", -1)
+		expr = strings.Replace(expr, `\?`, ".", -1)
+		expr = "^" + expr + "$"
+	}
+	reg, err := regexp.Compile(expr)
+	if err != nil {
+		lw.messageError("unable to parse type header pattern: %s", err)
+		reg = regexp.MustCompile("$^")
+	}
+	ret.Match = reg
+	return ret
+}
+
+func (lw *lexWrap) newChangeType(method, rawjs, idlType string) action {
 	ret := changeType{
 		abstractAction: abstractAction{
 			Ref: lw.ref(),
 		},
-		Name:  method,
-		RawJS: typ,
+		Name:    method,
+		RawJS:   rawjs,
+		IdlType: strings.TrimSpace(idlType),
 	}
 	return &ret
 }
 
+func (lw *lexWrap) newSkip(name string) action {
+	return &skip{
+		abstractAction: abstractAction{
+			Ref: lw.ref(),
+		},
+		Name: name,
+	}
+}
+
+func (lw *lexWrap) newCode(code string) action {
+	return &injectCode{
+		abstractAction: abstractAction{
+			Ref: lw.codeRef,
+		},
+		Code: code,
+	}
+}
+
 func (lw *lexWrap) newOn(match matchType, expr string, with action) action {
 	reg, err := regexp.Compile(expr)
 	if err != nil {
@@ -227,6 +411,54 @@ func (lw *lexWrap) newOn(match matchType, expr string, with action) action {
 	}
 }
 
+func (lw *lexWrap) newOnMember(kind memberKind, filters []memberFilter, expr string, with action) action {
+	reg, err := regexp.Compile(expr)
+	if err != nil {
+		lw.Error(fmt.Sprintf("unable to parse regexp: %s", err))
+		return nil
+	}
+	ret := &memberRule{
+		abstractAction: abstractAction{
+			Ref: lw.ref(),
+		},
+		Kind:    kind,
+		Match:   reg,
+		Filters: filters,
+	}
+	switch with := with.(type) {
+	case *property:
+		if with.Name != "name" {
+			lw.messageError("member rule can only change '.name', not '.%s'", with.Name)
+			return nil
+		}
+		ret.Rename = with.Value
+	case *skip:
+		ret.Skip = true
+	default:
+		lw.messageError("member rule can only use .name or @skip")
+		return nil
+	}
+	for _, f := range filters {
+		if f.What == "param" && kind != memberOperation {
+			lw.messageError("'param' can only be used with 'operation'")
+		}
+	}
+	return ret
+}
+
+func (lw *lexWrap) newMemberFilter(what, expr string) memberFilter {
+	ret := memberFilter{What: what}
+	if expr != "" {
+		reg, err := regexp.Compile(expr)
+		if err != nil {
+			lw.Error(fmt.Sprintf("unable to parse regexp: %s", err))
+			return ret
+		}
+		ret.Match = reg
+	}
+	return ret
+}
+
 func (lw *lexWrap) newProperty(name, value string) action {
 	ret := &property{
 		Name:  name,
@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// Verbose is printing extra information, e.g. what types a
+// wildcard type header is matching
+var Verbose = false
+
+// headerSetting is a property or rename done by a type header
+type headerSetting struct {
+	value  string
+	ref    ref
+	header string
+}
+
+// executePatterns is running wildcard and regular expression type
+// headers on all matching types. it's done before exact type headers
+// so they can override a value
+func (t *Transform) executePatterns(conv *types.Convert, data *actionData) {
+	if len(t.Patterns) == 0 {
+		return
+	}
+	matched := make(map[*onType][]string)
+	reported := make(map[string]bool)
+	for _, value := range conv.All {
+		if !value.TypeID().IsPublic() {
+			continue
+		}
+		name := value.Basic().Idl
+		group := groupName(value.SourceReference().Filename)
+		var sections []*onType
+		for _, change := range t.Patterns {
+			if groupName(change.Ref.inputFile()) == group && change.Match.MatchString(name) {
+				sections = append(sections, change)
+				matched[change] = append(matched[change], name)
+			}
+		}
+		if len(sections) == 0 {
+			continue
+		}
+		t.checkHeaderConflicts(name, sections, t.All[name], reported)
+		for _, change := range sections {
+			value.SourceReference().AddTrace("transform section '%s' at %s", change.Name, change.Ref)
+			t.executeOnType(value, change, name, data)
+		}
+	}
+	for _, change := range t.Patterns {
+		list := matched[change]
+		if len(list) == 0 {
+			t.messageError(change.Ref, "type header '%s' doesn't match any type", change.Name)
+		} else if Verbose {
+			fmt.Fprintf(t.Log, "%s: type header '%s' is matching %d type(s): %s\n",
+				change.Ref, change.Name, len(list), strings.Join(list, ", "))
+		}
+	}
+}
+
+// checkHeaderConflicts is reporting properties and renames that
+// are given different values by headers matching the same type.
+// two patterns is an error, an exact type header is overriding
+// a pattern and result in a warning
+func (t *Transform) checkHeaderConflicts(name string, patterns []*onType, exact *onType, reported map[string]bool) {
+	seen := make(map[string]headerSetting)
+	for _, change := range patterns {
+		for _, a := range change.Actions {
+			key, value := headerActionKey(a)
+			if key == "" {
+				continue
+			}
+			if prev, found := seen[key]; found && prev.value != value {
+				msg := fmt.Sprintf("'%s' is also set by type header '%s' at %s", key, prev.header, prev.ref)
+				if !reported[a.Reference().String()+msg] {
+					reported[a.Reference().String()+msg] = true
+					t.messageError(a.Reference(), "%s for %s", msg, name)
+				}
+			}
+			seen[key] = headerSetting{value: value, ref: a.Reference(), header: change.Name}
+		}
+	}
+	if exact == nil {
+		return
+	}
+	for _, a := range exact.Actions {
+		key, value := headerActionKey(a)
+		if prev, found := seen[key]; key != "" && found && prev.value != value {
+			printMessageWarning(a.Reference(), "'%s' is overriding value from type header '%s' at %s",
+				key, prev.header, prev.ref)
+		}
+	}
+}
+
+// headerActionKey is returning what an action is setting and the
+// value, or empty if the action can't be in conflict
+func headerActionKey(a action) (string, string) {
+	switch a := a.(type) {
+	case *property:
+		return "." + a.Name, a.Value
+	case *rename:
+		return a.Name, a.Value
+	}
+	return "", ""
+}
@@ -0,0 +1,262 @@
+package transform
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/gowebapi/webidl-bind/types"
+)
+
+// SkippedItem is a type or member that is excluded from output
+type SkippedItem struct {
+	// Name is type name or Type.member
+	Name string
+
+	// Source is the WebIDL definition
+	Source *types.Ref
+
+	// Transform is the line that skipped the item
+	Transform ref
+}
+
+// skip is removing a member from output
+type skip struct {
+	abstractAction
+	Name string
+}
+
+func (t *skip) OperateOn() scopeMode {
+	return scopeType
+}
+
+func (t *skip) ExecuteCallback(instance *types.Callback, data *actionData) {
+	data.notify.messageError(t.Ref, "callback doesn't have any members that can be skipped, use '.skip = true'")
+}
+
+func (t *skip) ExecuteDictionary(value *types.Dictionary, data *actionData) {
+	found := false
+	list := value.Members[:0]
+	for _, m := range value.Members {
+		if m.Name().Idl == t.Name {
+			t.record(value, m.SourceReference(), data)
+			found = true
+			continue
+		}
+		list = append(list, m)
+	}
+	value.Members = list
+	t.verify(found, data)
+}
+
+func (t *skip) ExecuteEnum(value *types.Enum, data *actionData) {
+	found := false
+	list := value.Values[:0]
+	for _, v := range value.Values {
+		if v.Idl == t.Name {
+			t.record(value, value.SourceReference(), data)
+			found = true
+			continue
+		}
+		list = append(list, v)
+	}
+	value.Values = list
+	t.verify(found, data)
+}
+
+func (t *skip) ExecuteInterface(value *types.Interface, data *actionData) {
+	found := false
+	vars := func(in []*types.IfVar) []*types.IfVar {
+		out := in[:0]
+		for _, v := range in {
+			if v.Name().Idl == t.Name {
+				t.record(value, v.SourceReference(), data)
+				found = true
+				continue
+			}
+			out = append(out, v)
+		}
+		return out
+	}
+	methods := func(in []*types.IfMethod) []*types.IfMethod {
+		out := in[:0]
+		for _, m := range in {
+			if m.Name().Idl == t.Name {
+				t.record(value, m.SourceReference(), data)
+				found = true
+				continue
+			}
+			out = append(out, m)
+		}
+		return out
+	}
+	consts := value.Consts[:0]
+	for _, c := range value.Consts {
+		if c.Name().Idl == t.Name {
+			t.record(value, c.SourceReference(), data)
+			found = true
+			continue
+		}
+		consts = append(consts, c)
+	}
+	value.Consts = consts
+	value.Vars = vars(value.Vars)
+	value.StaticVars = vars(value.StaticVars)
+	value.Events = vars(value.Events)
+	value.Method = methods(value.Method)
+	value.StaticMethod = methods(value.StaticMethod)
+	t.verify(found, data)
+}
+
+func (t *skip) record(on types.Type, source *types.Ref, data *actionData) {
+	source.AddTrace("skipped by %s", t.Ref)
+	data.notify.skipped(&SkippedItem{
+		Name:      on.Basic().Idl + "." + t.Name,
+		Source:    source,
+		Transform: t.Ref,
+	})
+}
+
+func (t *skip) verify(found bool, data *actionData) {
+	if !found {
+		data.notify.messageError(t.Ref, "unknown member '%s'", t.Name)
+	}
+}
+
+// typeSkip is the common part of '.skip' property
+type typeSkip struct{}
+
+func (t *typeSkip) get(value types.Type) string {
+	return strconv.FormatBool(!value.InUse())
+}
+
+func (t *typeSkip) set(value types.Type, text string) string {
+	skip, err := strconv.ParseBool(text)
+	if err != nil {
+		return fmt.Sprintf("invalid skip value '%s', valid are true or false", text)
+	}
+	if skip {
+		value.SetInUse(false)
+	}
+	return ""
+}
+
+// skipped is remembering a skipped type or member
+func (t *Transform) skipped(item *SkippedItem) {
+	t.Skipped = append(t.Skipped, item)
+}
+
+// skippedType is returning how a type was skipped, nil if it isn't
+func (t *Transform) skippedType(value types.Type) *SkippedItem {
+	for _, item := range t.Skipped {
+		if item.Source == value.SourceReference() {
+			return item
+		}
+	}
+	return nil
+}
+
+// assignSkippedToStatus is adding all skipped items to the spec
+// status they belong to
+func (t *Transform) assignSkippedToStatus() {
+	sort.Slice(t.Skipped, func(i, j int) bool { return t.Skipped[i].Name < t.Skipped[j].Name })
+	specs := make(map[string]*SpecStatus)
+	for _, s := range t.Status {
+		specs[s.Group] = s
+	}
+	for _, item := range t.Skipped {
+		group := calculateGroupNameFromFilename(item.Source.Filename)
+		if s, found := specs[group]; found {
+			s.Skipped = append(s.Skipped, item)
+		}
+	}
+}
+
+// checkSkippedReferences is writing a warning for every type that
+// is still using a skipped type
+func (t *Transform) checkSkippedReferences(conv *types.Convert) {
+	skipped := make(map[types.Type]*SkippedItem)
+	for _, item := range t.Skipped {
+		if typ, found := conv.Types[item.Name]; found && !typ.InUse() {
+			skipped[typ] = item
+		}
+	}
+	if len(skipped) == 0 {
+		return
+	}
+	for _, value := range conv.All {
+		if !value.InUse() || !value.TypeID().IsPublic() {
+			continue
+		}
+		from := value.Basic().Idl
+		check := func(member string, ref types.TypeRef, source *types.Ref) {
+			typeReferences(ref, func(used types.Type) {
+				if item, found := skipped[used]; found {
+					printMessageWarning(convertRef(source), "%s%s is using skipped type %s (skipped at %s)",
+						from, member, item.Name, item.Transform)
+				}
+			})
+		}
+		switch value := value.(type) {
+		case *types.Interface:
+			if value.Inherits != nil {
+				check("", value.Inherits, value.SourceReference())
+			}
+			for _, list := range [][]*types.IfVar{value.Vars, value.StaticVars, value.Events} {
+				for _, v := range list {
+					check("."+v.Name().Idl, v.Type, v.SourceReference())
+				}
+			}
+			methods := append([]*types.IfMethod{}, value.Method...)
+			methods = append(methods, value.StaticMethod...)
+			if value.Constructor != nil {
+				methods = append(methods, value.Constructor)
+			}
+			for _, m := range methods {
+				check("."+m.Name().Idl, m.Return, m.SourceReference())
+				for _, p := range m.Params {
+					check("."+m.Name().Idl, p.Type, m.SourceReference())
+				}
+			}
+		case *types.Dictionary:
+			for _, m := range value.Members {
+				check("."+m.Name().Idl, m.Type, m.SourceReference())
+			}
+		case *types.Callback:
+			check("", value.Return, value.SourceReference())
+			for _, p := range value.Parameters {
+				check("", p.Type, value.SourceReference())
+			}
+		}
+	}
+}
+
+// typeReferences is calling fn for all types that is used by a
+// type reference, e.g. both types in a union
+func typeReferences(value types.TypeRef, fn func(types.Type)) {
+	switch value := value.(type) {
+	case nil:
+	case types.Type:
+		fn(value)
+	case *types.SequenceType:
+		typeReferences(value.Elem, fn)
+	case *types.UnionType:
+		for _, t := range value.Types {
+			typeReferences(t, fn)
+		}
+	case *types.ParametrizedType:
+		for _, t := range value.Elems {
+			typeReferences(t, fn)
+		}
+	default:
+		if _, inner := value.DefaultParam(); inner != value {
+			typeReferences(inner, fn)
+		}
+	}
+}
+
+func printMessageWarning(ref ref, format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	fmt.Fprintf(os.Stderr, "warning:%s:%d:%s\n", ref.Filename, ref.Line, text)
+}
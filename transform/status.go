@@ -3,6 +3,7 @@ package transform
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -22,6 +23,9 @@ type SpecStatus struct {
 	Comment  string
 	Included bool
 
+	// Skipped is types and members excluded from output
+	Skipped []*SkippedItem
+
 	// outline modification files
 	files []ref
 
@@ -44,6 +48,11 @@ const statusTmplInput = `
 {{range .}}{{if not .Included}}|[{{.Title}}]({{.Url}})|{{if .Included}}Yes{{else}}No{{end}}|{{.Comment}}|
 {{end}}{{end}}{{end}}
 
+{{define "skipped"}}|Spec|Skipped|WebIDL|Transform|
+|----|---|---|---|
+{{range .}}{{$spec := .}}{{range .Skipped}}|[{{$spec.Title}}]({{$spec.Url}})|{{.Name}}|{{.Source}}|{{.Transform}}|
+{{end}}{{end}}{{end}}
+
 {{define "js-cross-ref"}}## {{.Letter}}
 
 |JavaScript|Go |
@@ -73,7 +82,7 @@ func createStatusData(files []ref, faction []action, list []types.Type, notify n
 
 	// execute actions
 	for _, a := range faction {
-		group := calculateGroupNameFromFilename(a.Reference().Filename)
+		group := calculateGroupNameFromFilename(a.Reference().inputFile())
 		s := specs[group]
 		a.ExecuteStatus(s, &actionData{notify: notify})
 	}
@@ -140,12 +149,15 @@ func (s *SpecStatus) verify(notify notifyMsg) {
 }
 
 func (t *Transform) WriteMarkdownStatus(filename string) error {
-	fmt.Println("saving spec status", filename)
-	md := markdownTmpl{}
+	fmt.Fprintln(t.Log, "saving spec status", filename)
+	md := markdownTmpl{log: t.Log}
 	md.contentTmpl("%HEADER%", "header", nil)
 	md.contentTmpl("%MISSING%", "missing", t.Status)
 	md.contentTmpl("%WORKING%", "working", t.Status)
-	return md.save(filename, "%WORKING%")
+	if len(t.Skipped) > 0 {
+		md.contentTmpl("%SKIPPED%", "skipped", t.Status)
+	}
+	return md.save(filename, "%WORKING%", "%SKIPPED%")
 }
 
 type JsIndexRef struct {
@@ -176,7 +188,7 @@ func createJavascriptCrossRef(all *types.Convert) []*JsIndexRef {
 }
 
 func (t *Transform) WriteCrossReference(filename string) error {
-	fmt.Println("saving cross reference file", filename)
+	fmt.Fprintln(t.Log, "saving cross reference file", filename)
 
 	sections := make(map[rune][]*JsIndexRef)
 	var letter rune
@@ -194,7 +206,7 @@ func (t *Transform) WriteCrossReference(filename string) error {
 		sections[letter] = append(sections[letter], v)
 	}
 
-	md := markdownTmpl{}
+	md := markdownTmpl{log: t.Log}
 	md.contentTmpl("%HEADER%", "header", nil)
 	var alphabet []byte
 	for _, v := range sorted {
@@ -218,6 +230,7 @@ type markdownTmpl struct {
 	err   error
 	list  map[string][]byte
 	order []string
+	log   io.Writer
 }
 
 func (t *markdownTmpl) add(key string, content []byte) {
@@ -235,19 +248,21 @@ func (t *markdownTmpl) contentTmpl(key, name string, data interface{}) {
 	t.add(key, content)
 }
 
-func (t *markdownTmpl) save(filename, ifMissing string) error {
+// save is writing the file using filename.tmpl as template. If the
+// template is missing, all existing ifMissing keys are written.
+func (t *markdownTmpl) save(filename string, ifMissing ...string) error {
 	if t.err != nil {
 		return t.err
 	}
-	if _, found := t.list[ifMissing]; !found {
-		panic("unable to find ifMissing: " + ifMissing)
+	if _, found := t.list[ifMissing[0]]; !found {
+		panic("unable to find ifMissing: " + ifMissing[0])
 	}
 
 	var content []byte
 	var err error
 	tname := filename + ".tmpl"
 	if content, err = ioutil.ReadFile(tname); err == nil {
-		fmt.Println("using template", tname)
+		fmt.Fprintln(t.log, "using template", tname)
 		sort.Strings(t.order)
 		for _, k := range t.order {
 			v := t.list[k]
@@ -256,7 +271,13 @@ func (t *markdownTmpl) save(filename, ifMissing string) error {
 	} else if !os.IsNotExist(err) {
 		return err
 	} else {
-		content = t.list[ifMissing]
+		parts := [][]byte{}
+		for _, key := range ifMissing {
+			if value, found := t.list[key]; found {
+				parts = append(parts, value)
+			}
+		}
+		content = bytes.Join(parts, []byte("\n\n"))
 	}
 	return ioutil.WriteFile(filename, content, 0664)
 }
@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError is a problem found when parsing a transform file
+type SyntaxError struct {
+	Filename string
+	Line     int
+	Column   int
+	Message  string
+
+	// Expected is tokens that would have been valid, if known
+	Expected []string
+
+	// Source is the line with the error
+	Source string
+}
+
+func (e *SyntaxError) Error() string {
+	msg := fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Message)
+	if len(e.Expected) > 0 {
+		msg += ", expected " + strings.Join(e.Expected, " or ")
+	}
+	return msg
+}
+
+// Snippet is the source line with a caret below the column
+func (e *SyntaxError) Snippet() string {
+	indent := []rune{}
+	for i, ch := range e.Source {
+		if utf8.RuneCountInString(e.Source[:i]) >= e.Column-1 {
+			break
+		}
+		if ch != '\t' {
+			ch = ' '
+		}
+		indent = append(indent, ch)
+	}
+	return "    " + e.Source + "\n    " + string(indent) + "^"
+}
+
+// SyntaxErrors is all syntax errors found in a transform file
+type SyntaxErrors []*SyntaxError
+
+func (list SyntaxErrors) Error() string {
+	lines := []string{}
+	for _, e := range list {
+		lines = append(lines, e.Error(), e.Snippet())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tokenNames is how parser tokens are written in error messages
+var tokenNames = map[string]string{
+	"$end":              "end of file",
+	"t_newline":         "end of line",
+	"t_heading_file":    "'#'",
+	"t_heading_type":    "'##'",
+	"t_comment":         "comment",
+	"t_ident":           "name",
+	"t_value":           "value",
+	"t_string":          "string",
+	"t_code":            "code block",
+	"t_pattern":         "type pattern",
+	"t_cmd_change_type": "@changetype",
+	"t_cmd_on":          "@on",
+	"t_cmd_patch":       "@patch",
+	"t_cmd_replace":     "@replace",
+	"t_cmd_event":       "@event",
+	"t_cmd_eventprop":   "@eventprop",
+	"t_cmd_addevent":    "@addevent",
+	"t_cmd_notevent":    "@notevent",
+	"t_cmd_skip":        "@skip",
+	"t_cmd_include":     "@include",
+	"t_cmd_define":      "@define",
+	"t_cmd_apply":       "@apply",
+	"t_cmd_end":         "@end",
+	"t_group_body":      "rule group body",
+}
+
+func tokenName(name string) string {
+	if value, found := tokenNames[name]; found {
+		return value
+	}
+	if strings.HasPrefix(name, "t_") {
+		// keywords
+		return "'" + name[2:] + "'"
+	}
+	return name
+}
+
+// parseYaccMessage is splitting a verbose yacc error message, like
+// "syntax error: unexpected t_ident, expecting '=' or t_newline", into
+// a readable message and the expected tokens
+func parseYaccMessage(msg string, last item) (string, []string) {
+	if !strings.HasPrefix(msg, "syntax error") {
+		return msg, nil
+	}
+	if last.typ == itemCommand && last.val == "end" {
+		return "@end without @define", nil
+	}
+	msg = strings.TrimPrefix(strings.TrimPrefix(msg, "syntax error"), ": ")
+	var expected []string
+	if idx := strings.Index(msg, ", expecting "); idx != -1 {
+		for _, name := range strings.Split(msg[idx+len(", expecting "):], " or ") {
+			expected = append(expected, tokenName(name))
+		}
+		msg = msg[:idx]
+	}
+	if !strings.HasPrefix(msg, "unexpected ") {
+		return "syntax error", expected
+	}
+	msg = "unexpected " + tokenName(strings.TrimPrefix(msg, "unexpected "))
+	switch last.typ {
+	case itemIdent, itemValue, itemString, itemPattern:
+		msg += fmt.Sprintf(" '%s'", strings.TrimSpace(last.val))
+	}
+	return msg, expected
+}
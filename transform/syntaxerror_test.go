@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntaxErrors(t *testing.T) {
+	md := `# ct
+
+.title = internal
+!bad
+
+## Canvas
+
+width = W
+@on interface foo: .x = y
+@event Click
+width
+@skip a b
+@changetype foo rawjs extra
+@patch idlconst extra
+
+## Opts
+
+.name = X
+`
+	_, _, err := executeTransform(changeTypeIdl, md, t)
+	list, ok := err.(SyntaxErrors)
+	if !assert.True(t, ok, err) {
+		return
+	}
+	found := []string{}
+	for _, e := range list {
+		found = append(found, e.Error())
+	}
+	assert.Equal(t, []string{
+		"ct.md:4:1: unknown line start",
+		"ct.md:9:15: invalid @on syntax, expected regular expression or one of: " +
+			"interface, enum, callback, dictionary, attribute, operation, constant, member, static, returns, param, extattr, type",
+		"ct.md:10:13: unexpected end of line, expected name",
+		"ct.md:11:6: expected to find '=' on rename line",
+		"ct.md:12:9: unexpected name 'b', expected end of line",
+		"ct.md:13:23: unexpected text after command",
+		"ct.md:14:17: unexpected text after command",
+	}, found)
+	assert.Equal(t, "    @skip a b\n            ^", list[4].Snippet())
+	assert.Equal(t, []string{"end of line"}, list[4].Expected)
+}
+
+func TestSyntaxErrorSnippet(t *testing.T) {
+	e := &SyntaxError{Filename: "a.md", Line: 2, Column: 3, Message: "oops", Source: "\tab c"}
+	assert.Equal(t, "a.md:2:3: oops", e.Error())
+	assert.Equal(t, "    \tab c\n    \t ^", e.Snippet())
+}
@@ -22,6 +22,11 @@ func init() {
 		lexCommandItem{"event", lexCommandEvent},
 		lexCommandItem{"addevent", lexCommandEvent},
 		lexCommandItem{"notevent", lexCommandEvent},
+		lexCommandItem{"skip", lexCommandEvent},
+		lexCommandItem{"include", lexValueOrString},
+		lexCommandItem{"define", lexCommandDefine},
+		lexCommandItem{"apply", lexCommandApply},
+		lexCommandItem{"end", emitNewLineGotoLineStart},
 	}
 }
 
@@ -31,11 +36,12 @@ main:
 	for {
 		// word evaluation
 		switch {
+		case isCodeFence(l):
+			return lexCodeBlock
 		case l.evalWord("##", true):
 			l.emit(itemTypeHeader)
 			ignoreWhitespaces(l)
-			tryConsumeIdent(l)
-			return requireRemaningToBeEmpty(l, lexLineStart)
+			return lexTypeHeaderName
 		case l.evalWord("#", true):
 			l.emit(itemFileHeader)
 			return lexValueOrString
@@ -68,6 +74,36 @@ main:
 	return nil
 }
 
+// lexTypeHeaderName is reading a type name, a wildcard pattern
+// like HTML*Element or a regular expression inside /.../
+func lexTypeHeaderName(l *lexer) stateFn {
+	if l.peek() == '/' {
+		rest, _ := l.readLine()
+		l.backup()
+		rest = strings.TrimRight(rest, " \t\r")
+		if len(rest) < 2 || !strings.HasSuffix(rest, "/") {
+			return l.errorf("expected regular expression type header inside /.../")
+		}
+		l.pos = l.start + len(rest)
+		l.emit(itemPattern)
+		return requireRemaningToBeEmpty(l, lexLineStart)
+	}
+	wildcard := false
+	l.acceptWithIdx(func(ch rune, idx int) bool {
+		if ch == '*' || ch == '?' {
+			wildcard = true
+			return true
+		}
+		return isIdentFirst(ch) || (idx > 0 && isIdentAny(ch))
+	})
+	if wildcard {
+		l.emit(itemPattern)
+	} else if l.pos > l.start {
+		l.emit(itemIdent)
+	}
+	return requireRemaningToBeEmpty(l, lexLineStart)
+}
+
 func lexCommandStart(l *lexer) stateFn {
 	// e.g. @on "HTML." : .package = github.com/gowebapi/webapi/html
 	l.ignore()
@@ -76,6 +112,10 @@ func lexCommandStart(l *lexer) stateFn {
 		if l.acceptWord(item.name) {
 			l.emit(itemCommand)
 			next := item.state
+			if isNewLine(l.peek()) {
+				// the command is reporting missing arguments
+				return next
+			}
 			next = requireWhitespace(l, next)
 			return next
 		}
@@ -83,26 +123,39 @@ func lexCommandStart(l *lexer) stateFn {
 	return l.errorf("unknown command or invalid syntax")
 }
 
+// keywords that can be used in @on before ':'
+var onKeywords = []string{
+	"interface", "enum", "callback", "dictionary",
+	"attribute", "operation", "constant", "member",
+	"static", "returns", "param", "extattr", "type",
+}
+
 func lexCommandOn(l *lexer) stateFn {
 	// e.g. @on "HTML." : .package = github.com/gowebapi/webapi/html
-	var match bool
-	match = match || l.acceptWord("interface")
-	match = match || l.acceptWord("enum")
-	match = match || l.acceptWord("callback")
-	match = match || l.acceptWord("dictionary")
-	if match {
-		l.emit(itemKeyword)
-		if !l.acceptWith(isWhitespace) {
-			return l.errorf("invalid command or syntax")
-		}
-		l.ignore()
-	} else {
+	// or @on operation returns "^Promise" "^get(.+)": .name = ${1}Async
+	for {
 		ignoreWhitespaces(l)
-	}
-
-	// consume regular expression
-	if requireString(l, nil); l.fail {
-		return nil
+		if consumed, failed := tryConsumeString(l); failed {
+			return nil
+		} else if consumed {
+			continue
+		}
+		ch := l.peek()
+		if ch == ':' || isNewLine(ch) {
+			break
+		}
+		match := false
+		for _, word := range onKeywords {
+			if l.acceptWord(word) {
+				match = true
+				break
+			}
+		}
+		if !match || !isWhitespace(l.peek()) {
+			return l.errorf("invalid @on syntax, expected regular expression or one of: %s",
+				strings.Join(onKeywords, ", "))
+		}
+		l.emit(itemKeyword)
 	}
 
 	// white spaces before ':'
@@ -145,10 +198,21 @@ func lexCommandChangeType(l *lexer) stateFn {
 
 	if l.acceptWord("rawjs") {
 		l.emit(itemKeyword)
-	} else {
-		return l.errorf("missing or invalid type argument. valid are 'rawjs'")
+		ignoreWhitespaces(l)
+		return emitNewLineGotoLineStart
 	}
-	ignoreWhitespaces(l)
+	// remaining of the line is a WebIDL type
+	for {
+		ch := l.next()
+		if isNewLine(ch) || ch == eof {
+			l.backup()
+			break
+		}
+	}
+	if strings.TrimSpace(l.input[l.start:l.pos]) == "" {
+		return l.errorf("missing type argument. valid are 'rawjs' or a WebIDL type")
+	}
+	l.emit(itemValue)
 	return emitNewLineGotoLineStart
 }
 
@@ -211,6 +275,123 @@ func lexCommandEvent(l *lexer) stateFn {
 	}
 }
 
+// lexCommandDefine is reading '@define name(param, ...)' and all
+// lines until @end as the rule group body
+func lexCommandDefine(l *lexer) stateFn {
+	line, start := l.line, strings.LastIndex(l.input[:l.pos], "\n")+1
+	if !tryConsumeIdent(l) {
+		return l.errorf("expected rule group name after @define")
+	}
+	return lexGroupArgs(l, tryConsumeIdent, func(l *lexer) stateFn {
+		return lexDefineBody(l, line, start)
+	})
+}
+
+// lexDefineBody is reading the rule group body, it's parsed first
+// when the group is applied as parameters can make it invalid
+func lexDefineBody(l *lexer, line, start int) stateFn {
+	if next := emitNewLineGotoLineStart(l); next == nil {
+		return nil
+	}
+	l.ignore()
+	first := l.line
+	body := []string{}
+	for {
+		text := l.input[l.pos:]
+		if idx := strings.IndexByte(text, '\n'); idx != -1 {
+			text = text[:idx]
+		}
+		if isCommandLine(text, "end") {
+			break
+		}
+		if isCommandLine(text, "define") {
+			// error is on this line, not the previous
+			l.wasNL = false
+			return l.errorf("@define inside @define is not supported")
+		}
+		text, more := l.readLine()
+		if !more {
+			l.wasNL = false
+			l.line, l.start = line, start
+			return l.errorf("missing @end for @define")
+		}
+		body = append(body, strings.TrimRight(text, "\r"))
+	}
+	l.items <- item{itemGroupBody, strings.Join(body, "\n") + "\n", first, l.start}
+	l.ignore()
+	return lexLineStart
+}
+
+// lexCommandApply is reading '@apply name(value, ...)'
+func lexCommandApply(l *lexer) stateFn {
+	if !tryConsumeIdent(l) {
+		return l.errorf("expected rule group name after @apply")
+	}
+	return lexGroupArgs(l, tryConsumeGroupValue, emitNewLineGotoLineStart)
+}
+
+// lexGroupArgs is reading an optional '(a, b)' after a rule group
+// name where every argument is read with arg
+func lexGroupArgs(l *lexer, arg func(l *lexer) bool, next stateFn) stateFn {
+	ignoreWhitespaces(l)
+	if !l.accept("(") {
+		return next
+	}
+	l.emit(itemSpecial)
+	ignoreWhitespaces(l)
+	if l.accept(")") {
+		l.emit(itemSpecial)
+		return next
+	}
+	for {
+		ignoreWhitespaces(l)
+		if !arg(l) {
+			return l.errorf("expected rule group argument")
+		}
+		ignoreWhitespaces(l)
+		switch {
+		case l.accept(","):
+			l.emit(itemSpecial)
+		case l.accept(")"):
+			l.emit(itemSpecial)
+			return next
+		default:
+			return l.errorf("expected ',' or ')' after rule group argument")
+		}
+	}
+}
+
+// tryConsumeGroupValue is reading a string or a value until ',' or ')'
+func tryConsumeGroupValue(l *lexer) bool {
+	if consumed, failed := tryConsumeString(l); consumed || failed {
+		return consumed
+	}
+	for {
+		ch := l.next()
+		if ch == ',' || ch == ')' || isNewLine(ch) {
+			l.backup()
+			break
+		}
+	}
+	value := strings.TrimRight(l.input[l.start:l.pos], " \t")
+	if value == "" {
+		return false
+	}
+	l.pos = l.start + len(value)
+	l.emit(itemValue)
+	return true
+}
+
+// isCommandLine is true if the line is given command
+func isCommandLine(line, cmd string) bool {
+	line = strings.TrimRight(line, " \t\r")
+	if !strings.HasPrefix(line, "@"+cmd) {
+		return false
+	}
+	rest := line[len(cmd)+1:]
+	return rest == "" || isWhitespace(rune(rest[0]))
+}
+
 func lexPropertyStart(l *lexer) stateFn {
 	tryConsumeIdent(l)
 	ignoreWhitespaces(l)
@@ -283,10 +464,49 @@ func lexCommentLine(l *lexer) stateFn {
 	}
 }
 
+// isCodeFence is checking if the line is starting a, possible
+// indented, ```go code block
+func isCodeFence(l *lexer) bool {
+	line := strings.TrimLeft(l.input[l.pos:], " \t")
+	return strings.HasPrefix(line, codeFence+"go")
+}
+
+const codeFence = "```"
+
+// lexCodeBlock is reading a fenced go code block. the indentation
+// of the opening fence is removed from every code line
+func lexCodeBlock(l *lexer) stateFn {
+	l.acceptWith(isWhitespace)
+	indent := l.input[l.start:l.pos]
+	line := l.line
+	l.acceptWord(codeFence + "go")
+	if rest, _ := l.readLine(); strings.TrimSpace(rest) != "" {
+		return l.errorf("unexpected text after %sgo: '%s'", codeFence, strings.TrimSpace(rest))
+	}
+	var code []string
+	for {
+		text, more := l.readLine()
+		text = strings.TrimRight(text, "\r")
+		if strings.TrimSpace(text) == codeFence {
+			break
+		}
+		if !more {
+			return l.errorf("missing end of code block, expected '%s'", codeFence)
+		}
+		code = append(code, strings.TrimPrefix(text, indent))
+	}
+	l.items <- item{itemCode, strings.Join(code, "\n") + "\n", line, l.start}
+	l.ignore()
+	l.items <- item{itemNewLine, "", l.line, l.pos}
+	return lexLineStart
+}
+
 func emitNewLineGotoLineStart(l *lexer) stateFn {
+	ignoreWhitespaces(l)
 	ch := l.next()
 	if !isNewLine(ch) {
-		panic("faulty")
+		l.backup()
+		return l.errorf("unexpected text after command")
 	}
 	l.emit(itemNewLine)
 	return lexLineStart
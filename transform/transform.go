@@ -2,7 +2,10 @@ package transform
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -18,6 +21,10 @@ type Transform struct {
 	// on multiple types.Type at once.
 	Global []*onType
 
+	// Patterns is wildcard and regular expression type headers
+	// that is changing all matching types
+	Patterns []*onType
+
 	// errors is number of errors currently printed
 	errors int
 
@@ -29,12 +36,25 @@ type Transform struct {
 
 	// JsCrossRef is a javascript go type cross reference
 	JsCrossRef []*JsIndexRef
+
+	// Skipped is all types and members excluded from output
+	Skipped []*SkippedItem
+
+	// information collected for lint
+	lint lintData
+
+	// Log is receiving progress messages and warnings
+	Log io.Writer
 }
 
 // ref is input source code reference
 type ref struct {
 	Filename string
 	Line     int
+
+	// Input is the transform file given as input when the line
+	// is coming from an included file
+	Input string
 }
 
 func convertRef(in *types.Ref) ref {
@@ -48,6 +68,14 @@ func (r ref) String() string {
 	return fmt.Sprint(r.Filename, ":", r.Line)
 }
 
+// inputFile is the transform file that decide the group of a line
+func (r ref) inputFile() string {
+	if r.Input != "" {
+		return r.Input
+	}
+	return r.Filename
+}
+
 // onType is the changes on a single types.Type
 type onType struct {
 	// Name of the type
@@ -55,6 +83,10 @@ type onType struct {
 	// Ref is input source reference
 	Ref ref
 
+	// Match is set when the header is a wildcard or regular
+	// expression instead of a type name
+	Match *regexp.Regexp
+
 	// Actions is the changes that will take place
 	Actions []action
 }
@@ -72,16 +104,19 @@ var defaultSpecializationNames = map[types.SpecializationType]string{
 func New() *Transform {
 	return &Transform{
 		All: make(map[string]*onType),
+		Log: os.Stdout,
 	}
 }
 
 func (t *Transform) Execute(conv *types.Convert) error {
-	fmt.Println("applying transformation on", len(t.All), "types")
+	fmt.Fprintln(t.Log, "applying transformation on", len(t.All), "types")
 	spec := t.executeFiles(conv)
 	if t.errors > 0 {
 		return errStop
 	}
 	eventMap := t.executeTypes(conv)
+	t.assignSkippedToStatus()
+	t.checkSkippedReferences(conv)
 	t.executePromises(conv)
 	t.checkAllSpecilizationAssignment(spec)
 	t.JsCrossRef = createJavascriptCrossRef(conv)
@@ -128,11 +163,13 @@ func (t *Transform) executeTypes(conv *types.Convert) map[string]struct{} {
 		conv:     conv,
 		eventMap: make(map[string]struct{}),
 	}
+	t.executePatterns(conv, data)
 	for name, change := range t.All {
 		value, ok := conv.Types[name]
 		if ok && value.TypeID().IsPublic() {
 			// interface, enum, dictionary etc
 			t.checkTypeGroup(change, value)
+			value.SourceReference().AddTrace("transform section '%s' at %s", name, change.Ref)
 			t.executeOnType(value, change, name, data)
 		} else if merge, ok := conv.Merge[name]; ok {
 			// e.g. mixin types
@@ -150,7 +187,10 @@ func (t *Transform) executeTypes(conv *types.Convert) map[string]struct{} {
 func (t *Transform) processMergeList(link types.MergeLink, change *onType, name string, data *actionData) {
 	for _, v := range link.MergeList() {
 		if inf, ok := v.(*types.Interface); ok {
+			inf.SourceReference().AddTrace("transform section '%s' at %s", name, change.Ref)
+			data.mergeName = name
 			t.executeOnType(inf, change, name, data)
+			data.mergeName = ""
 		} else {
 			t.processMergeList(v, change, name, data)
 		}
@@ -158,7 +198,7 @@ func (t *Transform) processMergeList(link types.MergeLink, change *onType, name
 }
 
 func (t *Transform) checkTypeGroup(change *onType, value types.Type) {
-	cg := groupName(change.Ref.Filename)
+	cg := groupName(change.Ref.inputFile())
 	sg := groupName(value.SourceReference().Filename)
 	if cg != sg {
 		t.messageError(change.Ref, "is changing output side of group. %s vs %s. type defined in %s",
@@ -258,6 +298,8 @@ func (t *Transform) evalIfProcess(value types.Type, a action, what matchType) bo
 		if !match.Match.MatchString(name) {
 			return false
 		}
+		value.SourceReference().AddTrace("matched regexp rule '%s' at %s", match.Match, match.Ref)
+		t.matched(match.Ref)
 	}
 	return true
 }
@@ -356,9 +398,9 @@ func (t *Transform) checkOnEventUsage(eventMap map[string]struct{}, conv *types.
 	if len(lines) > 0 {
 		sort.Strings(lines)
 		for _, msg := range lines {
-			fmt.Println(msg)
+			fmt.Fprintln(t.Log, msg)
 		}
-		fmt.Printf("warning: missing event data for %d types and total %d attributes\n", count, len(lines))
+		fmt.Fprintf(t.Log, "warning: missing event data for %d types and total %d attributes\n", count, len(lines))
 	}
 }
 
@@ -1,11 +1,13 @@
 // Code generated by goyacc -o yacc.go -p transform yacc.y. DO NOT EDIT.
 
 //line yacc.y:2
+
 package transform
 
 import __yyfmt__ "fmt"
 
 //line yacc.y:3
+
 import ()
 
 //line yacc.y:13
@@ -17,6 +19,10 @@ type transformSymType struct {
 	what    matchType
 	section []action
 	args    []arg
+	kind    memberKind
+	filter  memberFilter
+	filters []memberFilter
+	list    []string
 }
 
 const t_newline = 57346
@@ -26,20 +32,38 @@ const t_comment = 57349
 const t_ident = 57350
 const t_value = 57351
 const t_string = 57352
-const t_cmd_change_type = 57353
-const t_cmd_on = 57354
-const t_cmd_patch = 57355
-const t_cmd_replace = 57356
-const t_cmd_event = 57357
-const t_cmd_eventprop = 57358
-const t_cmd_addevent = 57359
-const t_cmd_notevent = 57360
-const t_interface = 57361
-const t_enum = 57362
-const t_callback = 57363
-const t_dictionary = 57364
-const t_idlconst = 57365
-const t_rawjs = 57366
+const t_code = 57353
+const t_pattern = 57354
+const t_cmd_change_type = 57355
+const t_cmd_on = 57356
+const t_cmd_patch = 57357
+const t_cmd_replace = 57358
+const t_cmd_event = 57359
+const t_cmd_eventprop = 57360
+const t_cmd_addevent = 57361
+const t_cmd_notevent = 57362
+const t_cmd_skip = 57363
+const t_interface = 57364
+const t_enum = 57365
+const t_callback = 57366
+const t_dictionary = 57367
+const t_idlconst = 57368
+const t_rawjs = 57369
+const t_attribute = 57370
+const t_operation = 57371
+const t_constant = 57372
+const t_member = 57373
+const t_static = 57374
+const t_returns = 57375
+const t_param = 57376
+const t_extattr = 57377
+const t_type = 57378
+const t_cmd_include = 57379
+const t_cmd_define = 57380
+const t_cmd_apply = 57381
+const t_cmd_end = 57382
+const t_group_body = 57383
+const t_error = 57384
 
 var transformToknames = [...]string{
 	"$end",
@@ -52,6 +76,8 @@ var transformToknames = [...]string{
 	"t_ident",
 	"t_value",
 	"t_string",
+	"t_code",
+	"t_pattern",
 	"t_cmd_change_type",
 	"t_cmd_on",
 	"t_cmd_patch",
@@ -60,17 +86,36 @@ var transformToknames = [...]string{
 	"t_cmd_eventprop",
 	"t_cmd_addevent",
 	"t_cmd_notevent",
+	"t_cmd_skip",
 	"t_interface",
 	"t_enum",
 	"t_callback",
 	"t_dictionary",
 	"t_idlconst",
 	"t_rawjs",
+	"t_attribute",
+	"t_operation",
+	"t_constant",
+	"t_member",
+	"t_static",
+	"t_returns",
+	"t_param",
+	"t_extattr",
+	"t_type",
+	"t_cmd_include",
+	"t_cmd_define",
+	"t_cmd_apply",
+	"t_cmd_end",
+	"t_group_body",
+	"t_error",
 	"'='",
 	"','",
+	"'('",
+	"')'",
 	"':'",
 	"'.'",
 }
+
 var transformStatenames = [...]string{}
 
 const transformEofCode = 1
@@ -78,103 +123,147 @@ const transformErrCode = 2
 const transformInitialStackSize = 16
 
 //line yacctab:1
-var transformExca = [...]int{
+var transformExca = [...]int8{
 	-1, 1,
 	1, -1,
 	-2, 0,
+	-1, 15,
+	1, 6,
+	6, 6,
+	-2, 0,
+	-1, 103,
+	1, 7,
+	6, 7,
+	-2, 0,
 }
 
 const transformPrivate = 57344
 
-const transformLast = 86
-
-var transformAct = [...]int{
-
-	20, 10, 52, 73, 13, 15, 74, 59, 7, 36,
-	72, 66, 25, 26, 37, 38, 31, 32, 33, 34,
-	36, 65, 68, 57, 62, 37, 38, 58, 81, 35,
-	46, 47, 48, 49, 11, 12, 79, 63, 53, 51,
-	35, 4, 6, 76, 7, 14, 70, 67, 64, 61,
-	56, 55, 54, 50, 44, 41, 60, 43, 42, 69,
-	9, 8, 2, 16, 1, 71, 5, 45, 75, 24,
-	78, 77, 23, 80, 22, 21, 30, 29, 28, 27,
-	19, 18, 17, 40, 3, 39,
+const transformLast = 152
+
+var transformAct = [...]uint8{
+	12, 35, 24, 112, 73, 15, 21, 83, 17, 140,
+	127, 8, 49, 120, 136, 44, 135, 33, 34, 50,
+	51, 40, 41, 42, 43, 52, 49, 104, 13, 14,
+	134, 94, 133, 50, 51, 99, 48, 97, 93, 52,
+	100, 45, 46, 47, 81, 113, 77, 132, 105, 141,
+	89, 118, 48, 63, 64, 65, 66, 137, 82, 67,
+	68, 69, 70, 86, 131, 121, 48, 87, 88, 130,
+	107, 108, 109, 110, 111, 13, 14, 129, 128, 125,
+	90, 6, 101, 4, 7, 142, 8, 72, 74, 115,
+	102, 103, 95, 92, 84, 80, 79, 78, 114, 116,
+	123, 124, 76, 75, 71, 60, 55, 126, 117, 85,
+	59, 58, 57, 56, 11, 10, 9, 18, 32, 31,
+	5, 30, 20, 16, 2, 1, 122, 98, 119, 139,
+	96, 91, 106, 62, 138, 61, 28, 143, 27, 26,
+	25, 29, 39, 38, 37, 36, 23, 22, 19, 54,
+	3, 53,
 }
-var transformPact = [...]int{
-
-	-1000, -1000, 37, 57, -1000, 56, 25, -1000, -1000, -1000,
-	-1000, -1000, -1000, 1, 49, -1000, 54, 53, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 46, 11, -1000, -1000, -1000,
-	-1000, 45, 30, 44, 43, 42, -2, 4, -21, 52,
-	-1000, 41, -1000, -1000, 0, 27, -1000, -1000, -1000, -1000,
-	40, -5, -1000, -16, 39, -1000, -3, 25, -1000, 38,
-	-1000, -1000, -1000, -17, 30, 30, 35, 30, 25, -1000,
-	26, 1, 12, -1000, -5, -1000, -1000, -1000, -1000, 18,
-	-1000, -1000,
+
+var transformPact = [...]int16{
+	-1000, -1000, 79, 112, -1000, 111, 110, 66, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 4, 100, -1000, 109, 108,
+	107, 106, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 97, 31, -1000, -1000, -1000, -1000, -1000,
+	96, 80, 95, 94, -1000, 66, 89, 88, 87, 1,
+	32, -41, 86, 105, -1000, 55, -1000, -1000, -1000, -1000,
+	41, 70, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 85, -6, -1000, -16, 84, -1000, -1000, -8, -10,
+	-3, 66, -1000, 82, -1000, -1000, -1000, -1000, -1000, -1000,
+	-20, 38, 80, 80, 81, 80, 104, 5, -1000, 19,
+	66, -1000, 69, 4, 18, -37, -1000, -1000, 68, 67,
+	59, 54, -1000, -6, -1000, -1000, -1000, 6, -1000, -14,
+	-1000, -1000, -30, -1000, -1000, 47, -1000, -12, -1000, -1000,
+	-1000, -1000, 9, -1000, 77, -1000, 66, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000,
 }
-var transformPgo = [...]int{
 
-	0, 63, 1, 85, 84, 83, 82, 81, 80, 0,
-	79, 78, 77, 76, 75, 74, 72, 69, 67, 4,
-	3, 6, 2, 64, 62, 45,
+var transformPgo = [...]uint8{
+	0, 117, 0, 151, 150, 149, 148, 147, 146, 2,
+	1, 145, 144, 143, 142, 141, 140, 139, 138, 136,
+	135, 134, 133, 132, 131, 5, 3, 45, 4, 130,
+	128, 127, 126, 125, 124, 123, 122, 121, 119, 118,
 }
-var transformR1 = [...]int{
 
-	0, 23, 24, 24, 24, 25, 25, 19, 19, 19,
-	19, 3, 6, 6, 6, 6, 6, 6, 6, 9,
-	9, 9, 9, 1, 4, 5, 7, 8, 14, 15,
-	16, 17, 20, 20, 21, 21, 22, 18, 18, 18,
-	18, 18, 10, 11, 12, 13, 2, 2,
+var transformR1 = [...]int8{
+	0, 33, 34, 34, 34, 34, 35, 35, 25, 25,
+	25, 25, 25, 25, 3, 6, 6, 6, 6, 6,
+	6, 6, 6, 36, 36, 36, 37, 38, 39, 29,
+	29, 29, 30, 30, 31, 31, 31, 32, 32, 9,
+	9, 9, 9, 9, 1, 4, 5, 5, 7, 7,
+	8, 8, 22, 22, 22, 22, 24, 24, 23, 23,
+	23, 23, 23, 21, 21, 16, 17, 18, 19, 14,
+	15, 26, 26, 27, 27, 28, 20, 20, 20, 20,
+	20, 10, 11, 12, 13, 2, 2,
 }
-var transformR2 = [...]int{
 
-	0, 5, 0, 2, 3, 0, 4, 0, 2, 3,
-	3, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 2, 2, 3, 5, 4, 2,
-	4, 2, 0, 1, 1, 3, 3, 0, 1, 1,
-	1, 1, 4, 3, 2, 5, 1, 1,
+var transformR2 = [...]int8{
+	0, 5, 0, 2, 3, 3, 0, 4, 0, 2,
+	3, 3, 3, 3, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 2, 6, 3, 0,
+	2, 3, 1, 3, 0, 2, 3, 1, 3, 1,
+	1, 1, 1, 1, 1, 2, 2, 2, 3, 3,
+	5, 6, 1, 1, 1, 1, 0, 2, 1, 2,
+	2, 2, 2, 1, 1, 4, 2, 4, 2, 2,
+	1, 0, 1, 1, 3, 3, 0, 1, 1, 1,
+	1, 4, 3, 2, 5, 1, 1,
 }
-var transformChk = [...]int{
-
-	-1000, -23, -24, -4, 4, -1, 5, 7, 4, 4,
-	-2, 9, 10, -19, -25, 4, -1, -6, -7, -8,
-	-9, -14, -15, -16, -17, 11, 12, -10, -11, -12,
-	-13, 15, 16, 17, 18, 28, 8, 13, 14, -3,
-	-5, 6, 4, 4, 8, -18, 19, 20, 21, 22,
-	8, -21, -22, 8, 8, 8, 8, 25, 23, 28,
-	4, 8, 24, 10, 8, 26, 27, 8, 25, -2,
-	8, -19, 27, -20, -21, -22, 8, -20, -2, 10,
-	-9, 10,
+
+var transformChk = [...]int16{
+	-1000, -33, -34, -4, 4, -1, 2, 5, 7, 4,
+	4, 4, -2, 9, 10, -25, -35, 4, -1, -6,
+	-36, 2, -7, -8, -9, -16, -17, -18, -19, -15,
+	-37, -38, -39, 13, 14, -10, -11, -12, -13, -14,
+	17, 18, 19, 20, 11, 37, 38, 39, 48, 8,
+	15, 16, 21, -3, -5, 6, 4, 4, 4, 4,
+	8, -20, -22, 22, 23, 24, 25, 28, 29, 30,
+	31, 8, -27, -28, 8, 8, 8, -2, 8, 8,
+	8, 43, 26, 48, 8, 4, 8, 12, 27, 9,
+	10, -24, 8, 44, 47, 8, -29, 45, -31, 45,
+	43, -2, 8, -25, 47, 10, -23, 32, 33, 34,
+	35, 36, -26, -27, -28, 8, -26, 4, 46, -30,
+	8, 46, -32, -2, -2, 10, -9, 47, 10, 10,
+	10, 10, 41, 46, 44, 46, 44, 10, -21, -10,
+	21, 40, 8, -2,
 }
-var transformDef = [...]int{
-
-	2, -2, 0, 0, 3, 0, 0, 23, 7, 4,
-	24, 46, 47, 5, 1, 8, 0, 0, 12, 13,
-	14, 15, 16, 17, 18, 0, 37, 19, 20, 21,
-	22, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	11, 0, 9, 10, 0, 0, 38, 39, 40, 41,
-	0, 29, 34, 0, 0, 31, 0, 0, 44, 0,
-	7, 25, 26, 0, 32, 0, 0, 32, 0, 43,
-	0, 6, 0, 28, 33, 35, 36, 30, 42, 0,
-	27, 45,
+
+var transformDef = [...]int8{
+	2, -2, 0, 0, 3, 0, 0, 0, 44, 8,
+	4, 5, 45, 85, 86, -2, 1, 9, 0, 0,
+	0, 0, 15, 16, 17, 18, 19, 20, 21, 22,
+	23, 24, 25, 0, 76, 39, 40, 41, 42, 43,
+	0, 0, 0, 0, 70, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 14, 0, 10, 11, 12, 13,
+	0, 0, 56, 77, 78, 79, 80, 52, 53, 54,
+	55, 0, 66, 73, 0, 0, 68, 26, 29, 34,
+	0, 0, 83, 0, 69, 8, 46, 47, 48, 49,
+	0, 0, 71, 0, 0, 71, 0, 0, 28, 0,
+	0, 82, 0, -2, 0, 0, 57, 58, 0, 0,
+	0, 0, 65, 72, 74, 75, 67, 0, 30, 0,
+	32, 35, 0, 37, 81, 0, 50, 0, 59, 60,
+	61, 62, 0, 31, 0, 36, 0, 84, 51, 63,
+	64, 27, 33, 38,
 }
-var transformTok1 = [...]int{
 
+var transformTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 26, 3, 28, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 27, 3,
-	3, 25,
+	45, 46, 3, 3, 44, 3, 48, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 47, 3,
+	3, 43,
 }
-var transformTok2 = [...]int{
 
+var transformTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
-	22, 23, 24,
+	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
+	42,
 }
-var transformTok3 = [...]int{
+
+var transformTok3 = [...]int8{
 	0,
 }
 
@@ -256,9 +345,9 @@ func transformErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := transformPact[state]
+	base := int(transformPact[state])
 	for tok := TOKSTART; tok-1 < len(transformToknames); tok++ {
-		if n := base + tok; n >= 0 && n < transformLast && transformChk[transformAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < transformLast && int(transformChk[int(transformAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -268,13 +357,13 @@ func transformErrorMessage(state, lookAhead int) string {
 
 	if transformDef[state] == -2 {
 		i := 0
-		for transformExca[i] != -1 || transformExca[i+1] != state {
+		for transformExca[i] != -1 || int(transformExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; transformExca[i] >= 0; i += 2 {
-			tok := transformExca[i]
+			tok := int(transformExca[i])
 			if tok < TOKSTART || transformExca[i+1] == 0 {
 				continue
 			}
@@ -305,30 +394,30 @@ func transformlex1(lex transformLexer, lval *transformSymType) (char, token int)
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = transformTok1[0]
+		token = int(transformTok1[0])
 		goto out
 	}
 	if char < len(transformTok1) {
-		token = transformTok1[char]
+		token = int(transformTok1[char])
 		goto out
 	}
 	if char >= transformPrivate {
 		if char < transformPrivate+len(transformTok2) {
-			token = transformTok2[char-transformPrivate]
+			token = int(transformTok2[char-transformPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(transformTok3); i += 2 {
-		token = transformTok3[i+0]
+		token = int(transformTok3[i+0])
 		if token == char {
-			token = transformTok3[i+1]
+			token = int(transformTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = transformTok2[1] /* unknown char */
+		token = int(transformTok2[1]) /* unknown char */
 	}
 	if transformDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", transformTokname(token), uint(char))
@@ -383,7 +472,7 @@ transformstack:
 	transformS[transformp].yys = transformstate
 
 transformnewstate:
-	transformn = transformPact[transformstate]
+	transformn = int(transformPact[transformstate])
 	if transformn <= transformFlag {
 		goto transformdefault /* simple state */
 	}
@@ -394,8 +483,8 @@ transformnewstate:
 	if transformn < 0 || transformn >= transformLast {
 		goto transformdefault
 	}
-	transformn = transformAct[transformn]
-	if transformChk[transformn] == transformtoken { /* valid shift */
+	transformn = int(transformAct[transformn])
+	if int(transformChk[transformn]) == transformtoken { /* valid shift */
 		transformrcvr.char = -1
 		transformtoken = -1
 		transformVAL = transformrcvr.lval
@@ -408,7 +497,7 @@ transformnewstate:
 
 transformdefault:
 	/* default state action */
-	transformn = transformDef[transformstate]
+	transformn = int(transformDef[transformstate])
 	if transformn == -2 {
 		if transformrcvr.char < 0 {
 			transformrcvr.char, transformtoken = transformlex1(transformlex, &transformrcvr.lval)
@@ -417,18 +506,18 @@ transformdefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if transformExca[xi+0] == -1 && transformExca[xi+1] == transformstate {
+			if transformExca[xi+0] == -1 && int(transformExca[xi+1]) == transformstate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			transformn = transformExca[xi+0]
+			transformn = int(transformExca[xi+0])
 			if transformn < 0 || transformn == transformtoken {
 				break
 			}
 		}
-		transformn = transformExca[xi+1]
+		transformn = int(transformExca[xi+1])
 		if transformn < 0 {
 			goto ret0
 		}
@@ -450,10 +539,10 @@ transformdefault:
 
 			/* find a state where "error" is a legal shift action */
 			for transformp >= 0 {
-				transformn = transformPact[transformS[transformp].yys] + transformErrCode
+				transformn = int(transformPact[transformS[transformp].yys]) + transformErrCode
 				if transformn >= 0 && transformn < transformLast {
-					transformstate = transformAct[transformn] /* simulate a shift of "error" */
-					if transformChk[transformstate] == transformErrCode {
+					transformstate = int(transformAct[transformn]) /* simulate a shift of "error" */
+					if int(transformChk[transformstate]) == transformErrCode {
 						goto transformstack
 					}
 				}
@@ -489,7 +578,7 @@ transformdefault:
 	transformpt := transformp
 	_ = transformpt // guard against "declared and not used"
 
-	transformp -= transformR2[transformn]
+	transformp -= int(transformR2[transformn])
 	// transformp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if transformp+1 >= len(transformS) {
@@ -500,16 +589,16 @@ transformdefault:
 	transformVAL = transformS[transformp+1]
 
 	/* consult goto table to find next state */
-	transformn = transformR1[transformn]
-	transformg := transformPgo[transformn]
+	transformn = int(transformR1[transformn])
+	transformg := int(transformPgo[transformn])
 	transformj := transformg + transformS[transformp].yys + 1
 
 	if transformj >= transformLast {
-		transformstate = transformAct[transformg]
+		transformstate = int(transformAct[transformg])
 	} else {
-		transformstate = transformAct[transformj]
-		if transformChk[transformstate] != -transformn {
-			transformstate = transformAct[transformg]
+		transformstate = int(transformAct[transformj])
+		if int(transformChk[transformstate]) != -transformn {
+			transformstate = int(transformAct[transformg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -517,259 +606,477 @@ transformdefault:
 
 	case 1:
 		transformDollar = transformS[transformpt-5 : transformpt+1]
-//line yacc.y:46
+//line yacc.y:62
 		{
 			presult(transformlex).AddFile(transformDollar[2].ontype, transformDollar[4].section)
 		}
-	case 6:
+	case 5:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:71
+		{
+			Errflag = 0
+		}
+	case 7:
 		transformDollar = transformS[transformpt-4 : transformpt+1]
-//line yacc.y:58
+//line yacc.y:78
 		{
 			presult(transformlex).AddType(transformDollar[2].ontype, transformDollar[4].section)
 		}
-	case 7:
+	case 8:
 		transformDollar = transformS[transformpt-0 : transformpt+1]
-//line yacc.y:63
+//line yacc.y:83
 		{
 			transformVAL.section = nil
 		}
-	case 8:
+	case 9:
 		transformDollar = transformS[transformpt-2 : transformpt+1]
-//line yacc.y:64
+//line yacc.y:84
 		{
 			transformVAL.section = transformDollar[1].section
 		}
-	case 9:
+	case 10:
 		transformDollar = transformS[transformpt-3 : transformpt+1]
-//line yacc.y:65
+//line yacc.y:85
 		{
 			transformVAL.section = transformDollar[1].section
 		}
-	case 10:
+	case 11:
 		transformDollar = transformS[transformpt-3 : transformpt+1]
-//line yacc.y:66
+//line yacc.y:86
 		{
 			transformVAL.section = append(transformVAL.section, transformDollar[2].action)
 		}
-	case 11:
-		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:69
-		{
-			transformVAL.ontype = transformDollar[1].ontype
-		}
 	case 12:
-		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:72
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:87
 		{
-			transformVAL.action = transformDollar[1].action
+			transformVAL.section = transformDollar[1].section
 		}
 	case 13:
-		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:73
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:89
 		{
-			transformVAL.action = transformDollar[1].action
+			// continue on next line to find more errors
+			transformVAL.section = transformDollar[1].section
+			Errflag = 0
 		}
 	case 14:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:74
+//line yacc.y:96
 		{
-			transformVAL.action = transformDollar[1].action
+			transformVAL.ontype = transformDollar[1].ontype
 		}
 	case 15:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:75
+//line yacc.y:99
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 16:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:76
+//line yacc.y:100
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 17:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:77
+//line yacc.y:101
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 18:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:78
+//line yacc.y:102
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 19:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:81
+//line yacc.y:103
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 20:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:82
+//line yacc.y:104
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 21:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:83
+//line yacc.y:105
 		{
 			transformVAL.action = transformDollar[1].action
 		}
 	case 22:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:84
+//line yacc.y:106
+		{
+			transformVAL.action = transformDollar[1].action
+		}
+	case 26:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:116
+		{
+			presult(transformlex).includeFile(transformDollar[2].val)
+		}
+	case 27:
+		transformDollar = transformS[transformpt-6 : transformpt+1]
+//line yacc.y:123
+		{
+			presult(transformlex).defineGroup(transformDollar[2].val, transformDollar[3].list, transformDollar[5].val)
+		}
+	case 28:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:129
+		{
+			presult(transformlex).applyGroup(transformDollar[2].val, transformDollar[3].list)
+		}
+	case 29:
+		transformDollar = transformS[transformpt-0 : transformpt+1]
+//line yacc.y:134
+		{
+			transformVAL.list = nil
+		}
+	case 30:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:135
+		{
+			transformVAL.list = nil
+		}
+	case 31:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:136
+		{
+			transformVAL.list = transformDollar[2].list
+		}
+	case 32:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:139
+		{
+			transformVAL.list = []string{transformDollar[1].val}
+		}
+	case 33:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:140
+		{
+			transformVAL.list = append(transformDollar[1].list, transformDollar[3].val)
+		}
+	case 34:
+		transformDollar = transformS[transformpt-0 : transformpt+1]
+//line yacc.y:143
+		{
+			transformVAL.list = nil
+		}
+	case 35:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:144
+		{
+			transformVAL.list = nil
+		}
+	case 36:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:145
+		{
+			transformVAL.list = transformDollar[2].list
+		}
+	case 37:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:148
+		{
+			transformVAL.list = []string{transformDollar[1].val}
+		}
+	case 38:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:149
+		{
+			transformVAL.list = append(transformDollar[1].list, transformDollar[3].val)
+		}
+	case 39:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:152
 		{
 			transformVAL.action = transformDollar[1].action
 		}
-	case 23:
+	case 40:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:87
+//line yacc.y:153
+		{
+			transformVAL.action = transformDollar[1].action
+		}
+	case 41:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:154
+		{
+			transformVAL.action = transformDollar[1].action
+		}
+	case 42:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:155
+		{
+			transformVAL.action = transformDollar[1].action
+		}
+	case 43:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:156
+		{
+			transformVAL.action = transformDollar[1].action
+		}
+	case 44:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:159
 		{
 			transformVAL.val = transformDollar[1].val
 		}
-	case 24:
+	case 45:
 		transformDollar = transformS[transformpt-2 : transformpt+1]
-//line yacc.y:91
+//line yacc.y:163
 		{
 			transformVAL.ontype = presult(transformlex).newFileHeader()
 		}
-	case 25:
+	case 46:
 		transformDollar = transformS[transformpt-2 : transformpt+1]
-//line yacc.y:98
+//line yacc.y:170
 		{
 			transformVAL.ontype = presult(transformlex).newTypeHeader(transformDollar[2].val)
 		}
-	case 26:
+	case 47:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:174
+		{
+			transformVAL.ontype = presult(transformlex).newPatternHeader(transformDollar[2].val)
+		}
+	case 48:
 		transformDollar = transformS[transformpt-3 : transformpt+1]
-//line yacc.y:105
+//line yacc.y:181
 		{
-			transformVAL.action = presult(transformlex).newChangeType(transformDollar[2].val, transformDollar[3].val)
+			transformVAL.action = presult(transformlex).newChangeType(transformDollar[2].val, transformDollar[3].val, "")
 		}
-	case 27:
+	case 49:
+		transformDollar = transformS[transformpt-3 : transformpt+1]
+//line yacc.y:185
+		{
+			transformVAL.action = presult(transformlex).newChangeType(transformDollar[2].val, "", transformDollar[3].val)
+		}
+	case 50:
 		transformDollar = transformS[transformpt-5 : transformpt+1]
-//line yacc.y:111
+//line yacc.y:191
 		{
 			transformVAL.action = presult(transformlex).newOn(transformDollar[2].what, transformDollar[3].val, transformDollar[5].action)
 		}
-	case 28:
+	case 51:
+		transformDollar = transformS[transformpt-6 : transformpt+1]
+//line yacc.y:195
+		{
+			transformVAL.action = presult(transformlex).newOnMember(transformDollar[2].kind, transformDollar[3].filters, transformDollar[4].val, transformDollar[6].action)
+		}
+	case 52:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:201
+		{
+			transformVAL.kind = memberAttribute
+		}
+	case 53:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:202
+		{
+			transformVAL.kind = memberOperation
+		}
+	case 54:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:203
+		{
+			transformVAL.kind = memberConstant
+		}
+	case 55:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:204
+		{
+			transformVAL.kind = memberAny
+		}
+	case 56:
+		transformDollar = transformS[transformpt-0 : transformpt+1]
+//line yacc.y:207
+		{
+			transformVAL.filters = nil
+		}
+	case 57:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:208
+		{
+			transformVAL.filters = append(transformDollar[1].filters, transformDollar[2].filter)
+		}
+	case 58:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:211
+		{
+			transformVAL.filter = presult(transformlex).newMemberFilter("static", "")
+		}
+	case 59:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:212
+		{
+			transformVAL.filter = presult(transformlex).newMemberFilter("returns", transformDollar[2].val)
+		}
+	case 60:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:213
+		{
+			transformVAL.filter = presult(transformlex).newMemberFilter("param", transformDollar[2].val)
+		}
+	case 61:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:214
+		{
+			transformVAL.filter = presult(transformlex).newMemberFilter("extattr", transformDollar[2].val)
+		}
+	case 62:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:215
+		{
+			transformVAL.filter = presult(transformlex).newMemberFilter("type", transformDollar[2].val)
+		}
+	case 63:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:219
+		{
+			transformVAL.action = transformDollar[1].action
+		}
+	case 64:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:221
+		{
+			transformVAL.action = presult(transformlex).newSkip("")
+		}
+	case 65:
 		transformDollar = transformS[transformpt-4 : transformpt+1]
-//line yacc.y:117
+//line yacc.y:227
 		{
 			transformVAL.action = presult(transformlex).newEvent(transformDollar[2].val, transformDollar[3].val, transformDollar[4].args)
 		}
-	case 29:
+	case 66:
 		transformDollar = transformS[transformpt-2 : transformpt+1]
-//line yacc.y:123
+//line yacc.y:233
 		{
 			transformVAL.action = presult(transformlex).setEventProp(transformDollar[2].args)
 		}
-	case 30:
+	case 67:
 		transformDollar = transformS[transformpt-4 : transformpt+1]
-//line yacc.y:129
+//line yacc.y:239
 		{
 			transformVAL.action = presult(transformlex).addEvent(transformDollar[2].val, transformDollar[3].val, transformDollar[4].args)
 		}
-	case 31:
+	case 68:
 		transformDollar = transformS[transformpt-2 : transformpt+1]
-//line yacc.y:135
+//line yacc.y:245
 		{
 			transformVAL.action = presult(transformlex).notEvent(transformDollar[2].val)
 		}
-	case 32:
+	case 69:
+		transformDollar = transformS[transformpt-2 : transformpt+1]
+//line yacc.y:251
+		{
+			transformVAL.action = presult(transformlex).newSkip(transformDollar[2].val)
+		}
+	case 70:
+		transformDollar = transformS[transformpt-1 : transformpt+1]
+//line yacc.y:258
+		{
+			transformVAL.action = presult(transformlex).newCode(transformDollar[1].val)
+		}
+	case 71:
 		transformDollar = transformS[transformpt-0 : transformpt+1]
-//line yacc.y:140
+//line yacc.y:263
 		{
 			transformVAL.args = nil
 		}
-	case 33:
+	case 72:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:141
+//line yacc.y:264
 		{
 			transformVAL.args = transformDollar[1].args
 		}
-	case 34:
+	case 73:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:144
+//line yacc.y:267
 		{
 			transformVAL.args = transformDollar[1].args
 		}
-	case 35:
+	case 74:
 		transformDollar = transformS[transformpt-3 : transformpt+1]
-//line yacc.y:145
+//line yacc.y:268
 		{
 			transformVAL.args = append(transformDollar[1].args, transformDollar[3].args...)
 		}
-	case 36:
+	case 75:
 		transformDollar = transformS[transformpt-3 : transformpt+1]
-//line yacc.y:149
+//line yacc.y:272
 		{
 			transformVAL.args = presult(transformlex).newArgumentIdent(transformDollar[1].val, transformDollar[3].val)
 		}
-	case 37:
+	case 76:
 		transformDollar = transformS[transformpt-0 : transformpt+1]
-//line yacc.y:154
+//line yacc.y:277
 		{
 			transformVAL.what = matchAll
 		}
-	case 38:
+	case 77:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:155
+//line yacc.y:278
 		{
 			transformVAL.what = matchInterface
 		}
-	case 39:
+	case 78:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:156
+//line yacc.y:279
 		{
 			transformVAL.what = matchEnum
 		}
-	case 40:
+	case 79:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:157
+//line yacc.y:280
 		{
 			transformVAL.what = matchCallback
 		}
-	case 41:
+	case 80:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:158
+//line yacc.y:281
 		{
 			transformVAL.what = matchDictionary
 		}
-	case 42:
+	case 81:
 		transformDollar = transformS[transformpt-4 : transformpt+1]
-//line yacc.y:162
+//line yacc.y:285
 		{
 			transformVAL.action = presult(transformlex).newProperty(transformDollar[2].val, transformDollar[4].val)
 		}
-	case 43:
+	case 82:
 		transformDollar = transformS[transformpt-3 : transformpt+1]
-//line yacc.y:168
+//line yacc.y:291
 		{
 			transformVAL.action = presult(transformlex).newRename(transformDollar[1].val, transformDollar[3].val)
 		}
-	case 44:
+	case 83:
 		transformDollar = transformS[transformpt-2 : transformpt+1]
-//line yacc.y:174
+//line yacc.y:297
 		{
 			transformVAL.action = presult(transformlex).newPatchIdlConst()
 		}
-	case 45:
+	case 84:
 		transformDollar = transformS[transformpt-5 : transformpt+1]
-//line yacc.y:180
+//line yacc.y:303
 		{
 			transformVAL.action = presult(transformlex).newReplace(transformDollar[3].val, transformDollar[4].val, transformDollar[5].val)
 		}
-	case 46:
+	case 85:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:185
+//line yacc.y:308
 		{
 			transformVAL.val = transformDollar[1].val
 		}
-	case 47:
+	case 86:
 		transformDollar = transformS[transformpt-1 : transformpt+1]
-//line yacc.y:186
+//line yacc.y:309
 		{
 			transformVAL.val = transformDollar[1].val
 		}
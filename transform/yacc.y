@@ -17,23 +17,39 @@ import (
     what    matchType
     section []action 
     args    []arg
+    kind    memberKind
+    filter  memberFilter
+    filters []memberFilter
+    list    []string
 }
 
 %token t_newline
 %token t_heading_file t_heading_type t_comment
-%token t_ident t_value t_string
+%token t_ident t_value t_string t_code t_pattern
 %token t_cmd_change_type t_cmd_on t_cmd_patch t_cmd_replace
-%token t_cmd_event t_cmd_eventprop t_cmd_addevent t_cmd_notevent
+%token t_cmd_event t_cmd_eventprop t_cmd_addevent t_cmd_notevent t_cmd_skip
 %token t_interface t_enum t_callback t_dictionary t_idlconst t_rawjs
+%token t_attribute t_operation t_constant t_member
+%token t_static t_returns t_param t_extattr t_type
+%token t_cmd_include t_cmd_define t_cmd_apply t_cmd_end t_group_body
+
+// t_error is a lexer error, it's not used in any rule to force
+// error recovery
+%token t_error
 
 %type <val> t_ident comment t_comment t_heading_file t_string value t_value
-%type <val> t_idlconst t_rawjs
+%type <val> t_idlconst t_rawjs t_code t_pattern t_group_body
 %type <ontype> newType fileHeader typeHeader
-%type <action> line changeType onType command property rename patch replace
+%type <action> line changeType onType command property rename patch replace skip code
 %type <action> event eventprop addevent notevent
 %type <what> onWhat
+%type <action> memberCommand
+%type <kind> memberKind
+%type <filter> memberFilter
+%type <filters> memberFilters
 %type <section> section
 %type <args> arguments argumentList argumentValue
+%type <list> groupParams groupParamList groupArgs groupArgList
 
 %left '='
 %left ','
@@ -51,6 +67,10 @@ document_start: document_start_junk fileHeader t_newline section document
 document_start_junk: /* empty */
     | document_start_junk t_newline
     | document_start_junk comment t_newline
+    | document_start_junk error t_newline
+    {
+        Errflag = 0
+    }
     ;
 
 document: /* empty */
@@ -64,6 +84,13 @@ section: /* empty */             { $$ = nil }
     | section t_newline          { $$ = $1 }
     | section comment t_newline  { $$ = $1 }
     | section line t_newline     { $$ = append($$, $2) }
+    | section directive t_newline { $$ = $1 }
+    | section error t_newline
+    {
+        // continue on next line to find more errors
+        $$ = $1
+        Errflag = 0
+    }
     ;
 
 newType: typeHeader       { $$ = $1 }
@@ -76,12 +103,57 @@ line: changeType       { $$ = $1 }
     | eventprop        { $$ = $1 }
     | addevent         { $$ = $1 }
     | notevent         { $$ = $1 }
+    | code             { $$ = $1 }
+    ;
+
+// @include, @define and @apply is changing the input, not the section
+directive: include
+    | define
+    | apply
+    ;
+
+include: t_cmd_include value
+    {
+        presult(transformlex).includeFile($2)
+    }
+    ;
+
+// the body is parsed when the group is applied
+define: t_cmd_define t_ident groupParams t_newline t_group_body t_cmd_end
+    {
+        presult(transformlex).defineGroup($2, $3, $5)
+    }
+    ;
+
+apply: t_cmd_apply t_ident groupArgs
+    {
+        presult(transformlex).applyGroup($2, $3)
+    }
+    ;
+
+groupParams: /* empty */            { $$ = nil }
+    | '(' ')'                       { $$ = nil }
+    | '(' groupParamList ')'        { $$ = $2 }
+    ;
+
+groupParamList: t_ident             { $$ = []string{$1} }
+    | groupParamList ',' t_ident    { $$ = append($1, $3) }
+    ;
+
+groupArgs: /* empty */              { $$ = nil }
+    | '(' ')'                       { $$ = nil }
+    | '(' groupArgList ')'          { $$ = $2 }
+    ;
+
+groupArgList: value                 { $$ = []string{$1} }
+    | groupArgList ',' value        { $$ = append($1, $3) }
     ;
 
 command: property      { $$ = $1 }
     | rename           { $$ = $1 }
     | patch            { $$ = $1 }
     | replace          { $$ = $1 }
+    | skip             { $$ = $1 }
     ;
 
 comment: t_comment       { $$ = $1 }
@@ -98,12 +170,20 @@ typeHeader: t_heading_type t_ident
     {
         $$ = presult(transformlex).newTypeHeader($2)
     }
+    | t_heading_type t_pattern
+    {
+        $$ = presult(transformlex).newPatternHeader($2)
+    }
     ;
 
 // change attribute type
 changeType: t_cmd_change_type t_ident t_rawjs
     {
-        $$ = presult(transformlex).newChangeType($2, $3)
+        $$ = presult(transformlex).newChangeType($2, $3, "")
+    }
+    | t_cmd_change_type t_ident t_value
+    {
+        $$ = presult(transformlex).newChangeType($2, "", $3)
     }
     ;
 
@@ -111,6 +191,36 @@ onType: t_cmd_on onWhat t_string ':' command
     {
         $$ = presult(transformlex).newOn($2, $3, $5)
     }
+    | t_cmd_on memberKind memberFilters t_string ':' memberCommand
+    {
+        $$ = presult(transformlex).newOnMember($2, $3, $4, $6)
+    }
+    ;
+
+// what kind of members a member rule is selecting
+memberKind: t_attribute { $$ = memberAttribute }
+    | t_operation       { $$ = memberOperation }
+    | t_constant        { $$ = memberConstant }
+    | t_member          { $$ = memberAny }
+    ;
+
+memberFilters: /* empty */          { $$ = nil }
+    | memberFilters memberFilter    { $$ = append($1, $2) }
+    ;
+
+memberFilter: t_static      { $$ = presult(transformlex).newMemberFilter("static", "") }
+    | t_returns t_string    { $$ = presult(transformlex).newMemberFilter("returns", $2) }
+    | t_param t_string      { $$ = presult(transformlex).newMemberFilter("param", $2) }
+    | t_extattr t_string    { $$ = presult(transformlex).newMemberFilter("extattr", $2) }
+    | t_type t_string       { $$ = presult(transformlex).newMemberFilter("type", $2) }
+    ;
+
+// change done on all selected members
+memberCommand: property     { $$ = $1 }
+    | t_cmd_skip
+    {
+        $$ = presult(transformlex).newSkip("")
+    }
     ;
 
 event: t_cmd_event t_ident t_ident arguments
@@ -137,6 +247,19 @@ notevent: t_cmd_notevent t_ident
     }
     ;
 
+skip: t_cmd_skip t_ident
+    {
+        $$ = presult(transformlex).newSkip($2)
+    }
+    ;
+
+// go code block
+code: t_code
+    {
+        $$ = presult(transformlex).newCode($1)
+    }
+    ;
+
 arguments: /* empty */  { $$ = nil }
     | argumentList      { $$ = $1 }
     ;
@@ -18,6 +18,7 @@ func (t *extractTypes) convertCallback(in *ast.Callback) *Callback {
 	params := t.convertParams(in.Parameters)
 	ret := &Callback{
 		standardType: standardType{
+			conv:        t.main,
 			ref:         createRef(in, t),
 			needRelease: false,
 		},
@@ -29,7 +30,7 @@ func (t *extractTypes) convertCallback(in *ast.Callback) *Callback {
 }
 
 func (t *Callback) Basic() BasicInfo {
-	return TransformBasic(t, t.basic)
+	return t.transformBasic(t, t.basic)
 }
 
 func (t *Callback) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -79,6 +80,7 @@ func (t *Callback) TemplateCopy(targetInfo BasicInfo) *Callback {
 	ref := *src.standardType.ref
 	dst := &Callback{
 		standardType: standardType{
+			conv:        src.standardType.conv,
 			inuse:       true,
 			needRelease: src.standardType.needRelease,
 			ref:         &ref,
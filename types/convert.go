@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/gowebapi/webidlparser/ast"
 	"github.com/gowebapi/webidlparser/parser"
@@ -16,17 +17,9 @@ var (
 	ErrStop = errors.New("too many errors")
 )
 
-// handle this as global variables is not ideal. However,
-// the usage of Basic type inside all templates make it
-// a lot of work. The issue will highlight it self if
-// we are using multple go routines.
-
-// TransformBasic is used to make modification to BasicInfo
-// structure when it's returned from all types. Main usage
-// is to change package name references when query for a type.
-var TransformBasic = func(t TypeRef, basic BasicInfo) BasicInfo {
-	return basic
-}
+// TraceChanges is enabling collection of change history in
+// Ref.Trace, used by explain mode.
+var TraceChanges = false
 
 type Type interface {
 	TypeRef
@@ -45,6 +38,10 @@ type Type interface {
 	SetBasic(basic BasicInfo)
 
 	TypeID() TypeID
+
+	// hand-written Go code that is appended to the output
+	AddInjectedCode(code *InjectedCode)
+	InjectedCode() []*InjectedCode
 }
 
 type Convert struct {
@@ -64,6 +61,14 @@ type Convert struct {
 
 	HaveError bool
 	setup     *Setup
+
+	// TransformBasic is used to make modification to BasicInfo
+	// structure when it's returned from callbacks, dictionaries,
+	// enums and interfaces. Main usage is to change package name
+	// references when query for a type. It is set by a backend
+	// while writing source code, nil is no modification. Different
+	// Convert instances can be used by backends at the same time.
+	TransformBasic func(t TypeRef, basic BasicInfo) BasicInfo
 }
 
 type UserMsgFn func(ref GetRef, format string, args ...interface{})
@@ -127,6 +132,42 @@ func (t *Convert) Parse(content []byte, setup *Setup) error {
 	return nil
 }
 
+// ParseType is converting a WebIDL type expression, e.g.
+// "(DOMString or Blob)?", into a linked type. It can be used
+// after Evaluate, e.g. during transformation. ref is used as
+// source reference in the returned type.
+func (t *Convert) ParseType(idl string, ref *Ref) (TypeRef, error) {
+	file := parser.Parse("typedef " + idl + " __parsetype;")
+	if trouble := ast.GetAllErrorNodes(file); len(trouble) > 0 {
+		return nil, fmt.Errorf("invalid type '%s': %s", idl, trouble[0].Message)
+	}
+	var def *ast.Typedef
+	if len(file.Declarations) == 1 {
+		def, _ = file.Declarations[0].(*ast.Typedef)
+	}
+	if def == nil {
+		return nil, fmt.Errorf("invalid type '%s'", idl)
+	}
+	// collect messages instead of reporting them
+	var msg []string
+	collect := func(ref GetRef, format string, args ...interface{}) {
+		msg = append(msg, fmt.Sprintf(format, args...))
+	}
+	oldSetup, oldError := t.setup, t.HaveError
+	defer func() { t.setup, t.HaveError = oldSetup, oldError }()
+	t.setup = &Setup{
+		Filename: ref.Filename,
+		Error:    collect,
+		Warning:  func(ref GetRef, format string, args ...interface{}) {},
+	}
+	list := &extractTypes{main: t, lineOffset: ref.Line - 1}
+	ret := convertType(def.Type, list).link(t, make(inuseLogic))
+	if len(msg) > 0 {
+		return nil, fmt.Errorf("type '%s': %s", idl, strings.Join(msg, ", "))
+	}
+	return ret, nil
+}
+
 func (t *Convert) parseContent(content []byte, list *extractTypes) error {
 	if len(content) == 0 {
 		return nil
@@ -226,7 +267,7 @@ func (conv *Convert) processPartialAndMixin() {
 			continue
 		}
 		if doc, ok := target.(*Interface); ok {
-			doc.mergeMixin(src, conv)
+			doc.mergeMixin(src, inc, conv)
 		} else {
 			conv.failing(inc, "target include existed to be an interface, not %T", target)
 		}
@@ -28,6 +28,7 @@ func (t *extractTypes) convertDictionary(in *ast.Dictionary) (*Dictionary, bool)
 	// t.assertTrue(in.Inherits == "", ref , "unsupported dictionary inherites of %s", in.Inherits)
 	ret := &Dictionary{
 		standardType: standardType{
+			conv:        t.main,
 			ref:         ref,
 			needRelease: false,
 		},
@@ -60,8 +61,9 @@ func (conv *extractTypes) convertDictMember(in *ast.Member) *DictMember {
 	}
 	return &DictMember{
 		nameAndLink: nameAndLink{
-			ref:  createRef(in, conv),
-			name: fromIdlToMethodName(in.Name),
+			ref:      createRef(in, conv),
+			name:     fromIdlToMethodName(in.Name),
+			extAttrs: annotationNames(in.Annotations),
 		},
 		Type:     convertType(in.Type, conv),
 		Required: in.Required,
@@ -69,7 +71,7 @@ func (conv *extractTypes) convertDictMember(in *ast.Member) *DictMember {
 }
 
 func (t *Dictionary) Basic() BasicInfo {
-	return TransformBasic(t, t.basic)
+	return t.transformBasic(t, t.basic)
 }
 
 func (t *Dictionary) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -119,6 +121,9 @@ func (t *Dictionary) merge(partial *Dictionary, conv *Convert) {
 	conv.assertTrue(partial.inheritsName == "", partial, "unsupported dictionary inherites on partial")
 	// TODO member elemination logic with duplicate is detected
 	t.standardType.mergeExtraRefs(partial.AllSourceReferences())
+	for _, m := range partial.Members {
+		m.ref.AddTrace("merged from partial dictionary %s at %s", partial.Basic().Idl, partial.SourceReference())
+	}
 	t.Members = append(t.Members, partial.Members...)
 }
 
@@ -147,6 +152,7 @@ func (t *Dictionary) templateCopy(targetInfo BasicInfo) *Dictionary {
 	ref := *src.standardType.ref
 	dst := &Dictionary{
 		standardType: standardType{
+			conv:        src.standardType.conv,
 			inuse:       true,
 			needRelease: src.standardType.needRelease,
 			ref:         &ref,
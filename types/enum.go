@@ -29,6 +29,7 @@ func (t *extractTypes) convertEnum(in *ast.Enum) *Enum {
 	t.warningTrue(len(in.Annotations) == 0, ref, "unsupported annotation")
 	ret := &Enum{
 		standardType: standardType{
+			conv:        t.main,
 			ref:         ref,
 			needRelease: false,
 		},
@@ -60,7 +61,7 @@ func (t *extractTypes) convertEnum(in *ast.Enum) *Enum {
 }
 
 func (t *Enum) Basic() BasicInfo {
-	return TransformBasic(t, t.basic)
+	return t.transformBasic(t, t.basic)
 }
 
 func (t *Enum) DefaultParam() (info *TypeInfo, inner TypeRef) {
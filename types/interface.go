@@ -125,6 +125,7 @@ var ignoredVarAnnotations = map[string]bool{
 func (t *extractTypes) convertInterface(in *ast.Interface) (*Interface, bool) {
 	ret := &Interface{
 		standardType: standardType{
+			conv:        t.main,
 			ref:         createRef(in, t),
 			needRelease: false,
 		},
@@ -274,8 +275,9 @@ func (conv *extractTypes) convertInterfaceVar(in *ast.Member, file, src string)
 
 	return &IfVar{
 		nameAndLink: nameAndLink{
-			ref:  ref,
-			name: fromIdlToMethodName(in.Name),
+			ref:      ref,
+			name:     fromIdlToMethodName(in.Name),
+			extAttrs: annotationNames(in.Annotations),
 		},
 		Type:        convertType(in.Type, conv),
 		Static:      in.Static,
@@ -295,6 +297,7 @@ func (conv *extractTypes) convertInterfaceMethod(in *ast.Member) (*IfMethod, *If
 		nameAndLink: nameAndLink{
 			ref: ref,
 			// name: assigned below
+			extAttrs: annotationNames(in.Annotations),
 		},
 		Return: convertType(in.Type, conv),
 		Static: in.Static,
@@ -344,7 +347,7 @@ func (conv *extractTypes) convertInterfaceMethod(in *ast.Member) (*IfMethod, *If
 }
 
 func (t *Interface) Basic() BasicInfo {
-	return TransformBasic(t, t.basic)
+	return t.transformBasic(t, t.basic)
 }
 
 func (t *Interface) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -427,24 +430,26 @@ func (t *Interface) SetBasic(value BasicInfo) {
 
 func (t *Interface) merge(m *Interface, conv *Convert) {
 	t.mergeExtraRefs(m.AllSourceReferences())
-	t.Consts = mergeConstants(t.Consts, m.Consts)
-	t.Vars = mergeVariables(t.Vars, m.Vars)
-	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars)
-	t.Method = mergeMethods(t.Method, m.Method)
-	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod)
-	t.Specialization = mergeMethods(t.Specialization, m.Specialization)
+	from := fmt.Sprintf("merged from partial interface %s at %s", m.Basic().Idl, m.SourceReference())
+	t.Consts = mergeConstants(t.Consts, m.Consts, from)
+	t.Vars = mergeVariables(t.Vars, m.Vars, from)
+	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars, from)
+	t.Method = mergeMethods(t.Method, m.Method, from)
+	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod, from)
+	t.Specialization = mergeMethods(t.Specialization, m.Specialization, from)
 	t.haveReplacableMethods = t.haveReplacableMethods || m.haveReplacableMethods
 }
 
-func (t *Interface) mergeMixin(m *mixin, conv *Convert) {
+func (t *Interface) mergeMixin(m *mixin, inc *includes, conv *Convert) {
 	m.mergedTo(t)
 	t.mergeExtraRefs(m.refs)
-	t.Consts = mergeConstants(t.Consts, m.Consts)
-	t.Vars = mergeVariables(t.Vars, m.Vars)
-	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars)
-	t.Method = mergeMethods(t.Method, m.Method)
-	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod)
-	t.Specialization = mergeMethods(t.Specialization, m.Specialization)
+	from := fmt.Sprintf("included from mixin %s by includes statement at %s", m.Name, inc.ref)
+	t.Consts = mergeConstants(t.Consts, m.Consts, from)
+	t.Vars = mergeVariables(t.Vars, m.Vars, from)
+	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars, from)
+	t.Method = mergeMethods(t.Method, m.Method, from)
+	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod, from)
+	t.Specialization = mergeMethods(t.Specialization, m.Specialization, from)
 	t.haveReplacableMethods = t.haveReplacableMethods || m.haveReplacableMethods
 }
 
@@ -457,6 +462,7 @@ func (t *Interface) TemplateCopy(targetInfo BasicInfo) *Interface {
 	ref := *src.standardType.ref
 	dst := &Interface{
 		standardType: standardType{
+			conv:        src.standardType.conv,
 			inuse:       true,
 			needRelease: src.standardType.needRelease,
 			ref:         &ref,
@@ -544,6 +550,10 @@ func (t *Interface) MergeList() []MergeLink {
 
 func (t *IfConst) copy() *IfConst {
 	dup := *t
+	if t.ref != nil {
+		r := *t.ref
+		dup.ref = &r
+	}
 	return &dup
 }
 
@@ -559,8 +569,9 @@ func (t *IfVar) Copy() *IfVar {
 	r := *t.ref
 	return &IfVar{
 		nameAndLink: nameAndLink{
-			name: t.nameAndLink.name,
-			ref:  &r,
+			name:     t.nameAndLink.name,
+			ref:      &r,
+			extAttrs: t.extAttrs,
 		},
 		Type:        t.Type,
 		Static:      t.Static,
@@ -589,8 +600,9 @@ func (t *IfMethod) Copy() *IfMethod {
 	r := *t.ref
 	dst := &IfMethod{
 		nameAndLink: nameAndLink{
-			name: t.nameAndLink.name,
-			ref:  &r,
+			name:     t.nameAndLink.name,
+			ref:      &r,
+			extAttrs: t.extAttrs,
 		},
 		Return:            t.Return,
 		Static:            t.Static,
@@ -649,31 +661,48 @@ func (t *IfMethod) identifySpecializationType(forWhat string) (SpecializationTyp
 	// return SpecNone, ""
 }
 
+// GetType is returning the return type
 func (t *IfMethod) GetType() TypeRef {
-	panic("not supported")
+	return t.Return
 }
 
+// SetType is failing, the return type is changed with SetReturn
 func (t *IfMethod) SetType(value TypeRef) string {
-	return "method can't change type"
+	return "method can't change type, use '<name>.return' to change return type"
 }
 
-func mergeConstants(dst, src []*IfConst) []*IfConst {
+// SetReturn is changing the return type
+func (t *IfMethod) SetReturn(value TypeRef) {
+	t.Return = value
+}
+
+// mergeConstants is appending a copy of all constants, from is
+// added to the change trace
+func mergeConstants(dst, src []*IfConst, from string) []*IfConst {
 	for _, v := range src {
-		dst = append(dst, v.copy())
+		c := v.copy()
+		c.ref.AddTrace("%s", from)
+		dst = append(dst, c)
 	}
 	return dst
 }
 
-func mergeVariables(dst, src []*IfVar) []*IfVar {
+func mergeVariables(dst, src []*IfVar, from string) []*IfVar {
 	for _, v := range src {
-		dst = append(dst, v.Copy())
+		c := v.Copy()
+		c.ref.AddTrace("%s", from)
+		dst = append(dst, c)
 	}
 	return dst
 }
 
-func mergeMethods(dst, src []*IfMethod) []*IfMethod {
+func mergeMethods(dst, src []*IfMethod, from string) []*IfMethod {
 	for _, v := range src {
-		dst = append(dst, v.Copy())
+		c := v.Copy()
+		if c != nil {
+			c.ref.AddTrace("%s", from)
+		}
+		dst = append(dst, c)
 	}
 	return dst
 }
@@ -99,12 +99,13 @@ func (t *extractTypes) convertIncludes(in *ast.Includes) *includes {
 func (t *mixin) merge(m *mixin, conv *Convert) {
 	m.mergedTo(t)
 	t.refs = append(t.refs, m.refs...)
-	t.Consts = mergeConstants(t.Consts, m.Consts)
-	t.Vars = mergeVariables(t.Vars, m.Vars)
-	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars)
-	t.Method = mergeMethods(t.Method, m.Method)
-	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod)
-	t.Specialization = mergeMethods(t.Specialization, m.Specialization)
+	from := fmt.Sprintf("merged from partial mixin %s at %s", m.Name, m.SourceReference())
+	t.Consts = mergeConstants(t.Consts, m.Consts, from)
+	t.Vars = mergeVariables(t.Vars, m.Vars, from)
+	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars, from)
+	t.Method = mergeMethods(t.Method, m.Method, from)
+	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod, from)
+	t.Specialization = mergeMethods(t.Specialization, m.Specialization, from)
 	t.haveReplacableMethods = t.haveReplacableMethods || m.haveReplacableMethods
 }
 
@@ -118,12 +119,13 @@ func (t *mixin) mergeIf(m *Interface, conv *Convert) {
 		conv.failing(m, "partial interface to mixin doesn't support constructor")
 	}
 	t.refs = append(t.refs, m.AllSourceReferences()...)
-	t.Consts = mergeConstants(t.Consts, m.Consts)
-	t.Vars = mergeVariables(t.Vars, m.Vars)
-	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars)
-	t.Method = mergeMethods(t.Method, m.Method)
-	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod)
-	t.Specialization = mergeMethods(t.Specialization, m.Specialization)
+	from := fmt.Sprintf("merged from partial interface %s at %s", m.Basic().Idl, m.SourceReference())
+	t.Consts = mergeConstants(t.Consts, m.Consts, from)
+	t.Vars = mergeVariables(t.Vars, m.Vars, from)
+	t.StaticVars = mergeVariables(t.StaticVars, m.StaticVars, from)
+	t.Method = mergeMethods(t.Method, m.Method, from)
+	t.StaticMethod = mergeMethods(t.StaticMethod, m.StaticMethod, from)
+	t.Specialization = mergeMethods(t.Specialization, m.Specialization, from)
 	t.haveReplacableMethods = t.haveReplacableMethods || m.haveReplacableMethods
 }
 
@@ -10,6 +10,9 @@ type Parameter struct {
 	Optional bool
 	Variadic bool
 	Name     string
+
+	// idl is the parameter name in WebIDL
+	idl string
 }
 
 func (p *Parameter) copy() *Parameter {
@@ -20,6 +23,7 @@ func (p *Parameter) copy() *Parameter {
 		Optional: p.Optional,
 		Variadic: p.Variadic,
 		Name:     p.Name,
+		idl:      p.idl,
 	}
 	return dst
 }
@@ -43,5 +47,34 @@ func (t *extractTypes) convertParam(in *ast.Parameter) *Parameter {
 		Type:     convertType(in.Type, t),
 		Optional: in.Optional,
 		Variadic: in.Variadic,
+		idl:      name,
+	}
+}
+
+// IdlName is the parameter name in WebIDL
+func (p *Parameter) IdlName() string {
+	return p.idl
+}
+
+func (p *Parameter) SourceReference() *Ref {
+	return p.ref
+}
+
+func (p *Parameter) GetType() TypeRef {
+	return p.Type
+}
+
+func (p *Parameter) SetType(value TypeRef) string {
+	p.Type = value
+	return ""
+}
+
+// FindParameter is returning a parameter with given WebIDL name
+func FindParameter(list []*Parameter, name string) *Parameter {
+	for _, p := range list {
+		if p.idl == name {
+			return p
+		}
 	}
+	return nil
 }
@@ -132,7 +132,7 @@ func (t *AnyType) Basic() BasicInfo {
 		Internal: "<any>",
 		Template: "any",
 	}
-	return TransformBasic(t, ret)
+	return ret
 }
 
 func (t *AnyType) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -336,7 +336,7 @@ func (t *PrimitiveType) Basic() BasicInfo {
 		Internal: "<primitive-internal-name>",
 		Template: "primitive",
 	}
-	return TransformBasic(t, basic)
+	return basic
 }
 
 func (t *PrimitiveType) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -499,7 +499,7 @@ func newTypedArrayType(primitive *PrimitiveType) *TypedArrayType {
 }
 
 func (t *TypedArrayType) Basic() BasicInfo {
-	return TransformBasic(t, t.basic)
+	return t.basic
 }
 
 func (t *TypedArrayType) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -582,7 +582,7 @@ func newUnionType(in *ast.UnionType, exrTypes *extractTypes) *UnionType {
 }
 
 func (t *UnionType) Basic() BasicInfo {
-	return TransformBasic(t, t.basic)
+	return t.basic
 }
 
 func (t *UnionType) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -656,7 +656,7 @@ func (t *voidType) Basic() BasicInfo {
 		Internal: "void",
 		Template: "void",
 	}
-	return TransformBasic(t, basic)
+	return basic
 }
 
 func (t *voidType) DefaultParam() (info *TypeInfo, inner TypeRef) {
@@ -95,6 +95,14 @@ type Ref struct {
 	Filename      string
 	Line          int
 	TransformFile string
+
+	// RenamedBy is the transform file and line that changed the
+	// Go name, if any
+	RenamedBy string
+
+	// Trace is a list of changes done to the declaration, only
+	// collected when TraceChanges is true
+	Trace []string
 }
 
 type GetRef interface {
@@ -102,15 +110,30 @@ type GetRef interface {
 }
 
 type standardType struct {
+	conv        *Convert
 	ref         *Ref
 	extraRefs   []*Ref
 	needRelease bool
 	inuse       bool
+	injected    []*InjectedCode
+}
+
+// InjectedCode is hand-written Go source code from a transform file
+// that is appended to the generated output of a type
+type InjectedCode struct {
+	// Ref is the transform file and line of the code block
+	Ref *Ref
+
+	// Code is the Go source, declarations only
+	Code string
 }
 
 type nameAndLink struct {
 	ref  *Ref
 	name MethodName
+
+	// extended attribute names, e.g. SameObject
+	extAttrs []string
 }
 
 type changeTemplateType struct {
@@ -155,6 +178,14 @@ func convertIntoValidVariableName(input string) string {
 	return out
 }
 
+func annotationNames(list []*ast.Annotation) []string {
+	var ret []string
+	for _, a := range list {
+		ret = append(ret, a.Name)
+	}
+	return ret
+}
+
 func fromIdlToTypeName(pkg string, name string, tmpl string) BasicInfo {
 	name = getIdlName(name)
 	ret := BasicInfo{
@@ -310,6 +341,12 @@ func (t *nameAndLink) SetName(value *MethodName) {
 	t.name = *value
 }
 
+// ExtendedAttributes is returning the name of all WebIDL extended
+// attributes on the member
+func (t *nameAndLink) ExtendedAttributes() []string {
+	return t.extAttrs
+}
+
 func (t *Ref) sourceLessThan(other *Ref) bool {
 	if t.Filename != other.Filename {
 		return t.Filename < other.Filename
@@ -325,6 +362,25 @@ func (t *Ref) String() string {
 	return fmt.Sprintf("%s:%d", t.Filename, t.Line)
 }
 
+// AddTrace is adding a line to the change history of the
+// declaration, if TraceChanges is enabled
+func (t *Ref) AddTrace(format string, args ...interface{}) {
+	if !TraceChanges || t == nil {
+		return
+	}
+	// a copied reference is sharing the slice, force a new array
+	list := t.Trace[:len(t.Trace):len(t.Trace)]
+	t.Trace = append(list, fmt.Sprintf(format, args...))
+}
+
+// transformBasic is applying Convert.TransformBasic
+func (t *standardType) transformBasic(typ TypeRef, basic BasicInfo) BasicInfo {
+	if t.conv == nil || t.conv.TransformBasic == nil {
+		return basic
+	}
+	return t.conv.TransformBasic(typ, basic)
+}
+
 func (t *standardType) AllSourceReferences() []*Ref {
 	if t.extraRefs == nil {
 		return []*Ref{t.ref}
@@ -361,6 +417,14 @@ func (t *standardType) SourceReference() *Ref {
 	return t.ref
 }
 
+func (t *standardType) AddInjectedCode(code *InjectedCode) {
+	t.injected = append(t.injected, code)
+}
+
+func (t *standardType) InjectedCode() []*InjectedCode {
+	return t.injected
+}
+
 func (t *inuseLogic) push(name string, ref GetRef, conv *Convert) bool {
 	_, ret := (*t)[name]
 	if ret {